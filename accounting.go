@@ -0,0 +1,190 @@
+package av
+
+import (
+	"runtime/cgo"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/ssttevee/go-av/avcodec"
+	"github.com/ssttevee/go-av/avfilter"
+	"github.com/ssttevee/go-av/avformat"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// ObjectKind is a kind of object that holds C memory on behalf of the
+// package.
+type ObjectKind int
+
+const (
+	PacketObject ObjectKind = iota
+	FrameObject
+	CodecContextObject
+	FormatContextObject
+	FilterGraphObject
+	BitstreamFilterContextObject
+
+	// HandleObject is a cgo.Handle that keeps Go values reachable from C,
+	// like the readers and writers of custom io contexts.
+	HandleObject
+)
+
+var objectKindNames = map[ObjectKind]string{
+	PacketObject:                 "packet",
+	FrameObject:                  "frame",
+	CodecContextObject:           "codec context",
+	FormatContextObject:          "format context",
+	FilterGraphObject:            "filter graph",
+	BitstreamFilterContextObject: "bitstream filter context",
+	HandleObject:                 "cgo handle",
+}
+
+func (k ObjectKind) String() string {
+	return objectKindNames[k]
+}
+
+// ObjectStats describes the live objects of a kind.
+type ObjectStats struct {
+	Count int
+
+	// Bytes is an approximation of the C memory held by the objects. Data
+	// that is shared between objects, like the payload of a cloned packet,
+	// is counted for each of them.
+	Bytes int64
+}
+
+// MemoryStats maps kinds of objects to their stats.
+type MemoryStats map[ObjectKind]ObjectStats
+
+type accountedObject struct {
+	kind ObjectKind
+	key  uintptr
+}
+
+var accounting struct {
+	enabled int32
+
+	mu sync.Mutex
+	// objects maps tracked objects to their C pointer, if any
+	objects map[accountedObject]unsafe.Pointer
+}
+
+// EnableMemoryAccounting starts tracking the objects that are created by the
+// package. Objects that were created before are not tracked. Accounting adds
+// a lock to every allocation, so it is meant for debugging and tests.
+func EnableMemoryAccounting() {
+	accounting.mu.Lock()
+	defer accounting.mu.Unlock()
+
+	if accounting.objects == nil {
+		accounting.objects = map[accountedObject]unsafe.Pointer{}
+	}
+
+	atomic.StoreInt32(&accounting.enabled, 1)
+}
+
+// DisableMemoryAccounting stops tracking objects and forgets the tracked ones.
+func DisableMemoryAccounting() {
+	accounting.mu.Lock()
+	defer accounting.mu.Unlock()
+
+	atomic.StoreInt32(&accounting.enabled, 0)
+	accounting.objects = nil
+}
+
+func memoryAccountingEnabled() bool {
+	return atomic.LoadInt32(&accounting.enabled) != 0
+}
+
+func trackObject(kind ObjectKind, key uintptr, ptr unsafe.Pointer) {
+	if !memoryAccountingEnabled() {
+		return
+	}
+
+	accounting.mu.Lock()
+	defer accounting.mu.Unlock()
+
+	if accounting.objects != nil {
+		accounting.objects[accountedObject{kind: kind, key: key}] = ptr
+	}
+}
+
+func untrackObject(kind ObjectKind, key uintptr) {
+	if !memoryAccountingEnabled() {
+		return
+	}
+
+	accounting.mu.Lock()
+	defer accounting.mu.Unlock()
+
+	delete(accounting.objects, accountedObject{kind: kind, key: key})
+}
+
+// trackPointer tracks an object that is allocated in C memory.
+func trackPointer(kind ObjectKind, ptr unsafe.Pointer) {
+	trackObject(kind, uintptr(ptr), ptr)
+}
+
+// untrackPointer must be called before the object is freed, so that
+// ReadMemoryStats does not read freed memory.
+func untrackPointer(kind ObjectKind, ptr unsafe.Pointer) {
+	untrackObject(kind, uintptr(ptr))
+}
+
+func newHandle(v interface{}) cgo.Handle {
+	h := cgo.NewHandle(v)
+	trackObject(HandleObject, uintptr(h), nil)
+	return h
+}
+
+func deleteHandle(h cgo.Handle) {
+	untrackObject(HandleObject, uintptr(h))
+	h.Delete()
+}
+
+// ReadMemoryStats returns the stats of the objects that are alive and were
+// created while accounting was enabled.
+func ReadMemoryStats() MemoryStats {
+	accounting.mu.Lock()
+	defer accounting.mu.Unlock()
+
+	stats := MemoryStats{}
+	for obj, ptr := range accounting.objects {
+		s := stats[obj.kind]
+		s.Count++
+		s.Bytes += objectSize(obj.kind, ptr)
+		stats[obj.kind] = s
+	}
+
+	return stats
+}
+
+func objectSize(kind ObjectKind, ptr unsafe.Pointer) int64 {
+	switch kind {
+	case PacketObject:
+		return int64(unsafe.Sizeof(avcodec.Packet{})) + int64((*avcodec.Packet)(ptr).Size)
+
+	case FrameObject:
+		frame := &Frame{_frame: (*avutil.Frame)(ptr)}
+		size := int64(unsafe.Sizeof(avutil.Frame{}))
+		for i := 0; i < frame.Planes(); i++ {
+			size += int64(frame.planeSize(i))
+		}
+
+		return size
+
+	case CodecContextObject:
+		return int64(unsafe.Sizeof(avcodec.Context{}))
+
+	case FormatContextObject:
+		return int64(unsafe.Sizeof(avformat.Context{}))
+
+	case FilterGraphObject:
+		return int64(unsafe.Sizeof(avfilter.Graph{}))
+
+	case BitstreamFilterContextObject:
+		return int64(unsafe.Sizeof(avcodec.BitstreamFilterContext{}))
+	}
+
+	return 0
+}
@@ -0,0 +1,44 @@
+package av
+
+import (
+	"fmt"
+
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// AudioFormat describes the layout of audio samples in a frame.
+type AudioFormat struct {
+	SampleFormat  avutil.SampleFormat
+	SampleRate    int32
+	ChannelLayout uint64
+
+	// TimeBase is the time base of frame timestamps. If it is zero,
+	// 1/SampleRate is assumed.
+	TimeBase avutil.Rational
+}
+
+func (f AudioFormat) Channels() int32 {
+	return avutil.GetChannelLayoutNbChannels(f.ChannelLayout)
+}
+
+// BufferSourceArgs returns the arguments for an abuffer filter that accepts
+// frames of this format.
+func (f AudioFormat) BufferSourceArgs() string {
+	return fmt.Sprintf("time_base=%s:sample_rate=%d:sample_fmt=%s:channel_layout=0x%x", f.timeBase(), f.SampleRate, f.SampleFormat, f.ChannelLayout)
+}
+
+func (f AudioFormat) timeBase() avutil.Rational {
+	if f.TimeBase.IsZero() {
+		return Rat(1, f.SampleRate)
+	}
+
+	return f.TimeBase
+}
+
+func channelLayoutOrDefault(layout uint64, channels int32) uint64 {
+	if layout == 0 {
+		return uint64(avutil.GetDefaultChannelLayout(channels))
+	}
+
+	return layout
+}
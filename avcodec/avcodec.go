@@ -19,6 +19,7 @@ import "C"
 // +gen convtype struct_AVCodecParameters Parameters
 // +gen convtype struct_AVBitStreamFilter BitstreamFilter
 // +gen convtype struct_AVBSFContext BitstreamFilterContext
+// +gen convtype struct_AVPacketSideData PacketSideData
 
 // +gen fieldtype struct_AVCodec id ID
 // +gen fieldtype struct_AVCodec pix_fmts *github.com/ssttevee/go-av/avutil.PixelFormat
@@ -30,6 +31,8 @@ import "C"
 // +gen fieldtype struct_AVCodecContext sample_fmt github.com/ssttevee/go-av/avutil.SampleFormat
 
 // +gen fieldtype struct_AVCodecParameters codec_id ID
+
+// +gen fieldtype struct_AVPacketSideData _type PacketSideDataType
 // +gen fieldtype struct_AVCodecParameters color_range github.com/ssttevee/go-av/avutil.ColorRange
 // +gen fieldtype struct_AVCodecParameters color_primaries github.com/ssttevee/go-av/avutil.ColorPrimaries
 
@@ -44,15 +47,22 @@ import "C"
 // +gen wrapfunc avcodec_receive_packet ReceivePacket
 // +gen wrapfunc avcodec_send_frame SendFrame
 // +gen wrapfunc avcodec_receive_frame ReceiveFrame
+// +gen wrapfunc avcodec_flush_buffers FlushBuffers
 // +gen wrapfunc avcodec_parameters_copy CopyParameters
 // +gen wrapfunc avcodec_get_name getName
 // +gen wrapfunc avcodec_find_decoder FindDecoder
 // +gen wrapfunc avcodec_find_encoder FindEncoder
+// +gen wrapfunc av_codec_iterate IterateCodecs
+// +gen wrapfunc av_codec_is_encoder IsEncoder
+// +gen wrapfunc av_codec_is_decoder IsDecoder
 
 // +gen wrapfunc av_packet_alloc NewPacket
 // +gen wrapfunc av_packet_free FreePacket
 // +gen wrapfunc av_packet_ref RefPacket
 // +gen wrapfunc av_packet_unref UnrefPacket
+// +gen wrapfunc av_new_packet AllocPacketData
+// +gen wrapfunc av_packet_new_side_data NewPacketSideData
+// +gen wrapfunc av_packet_side_data_name getPacketSideDataName
 
 // +gen wrapfunc av_bsf_alloc NewBitstreamFilter
 // +gen wrapfunc av_bsf_free FreeBitstreamFilter
@@ -60,9 +70,12 @@ import "C"
 // +gen wrapfunc av_bsf_receive_packet ReceiveBitstreamFilterPacket
 // +gen wrapfunc av_bsf_send_packet SendBitstreamFilterPacket
 // +gen wrapfunc av_bsf_get_by_name GetBitstreamFilterByName
+// +gen wrapfunc av_bsf_iterate IterateBitstreamFilters
 
 // +gen paramtype avcodec_get_name 0 ID
 // +gen paramtype avcodec_find_decoder 0 ID
 // +gen paramtype avcodec_find_encoder 0 ID
+// +gen paramtype av_packet_new_side_data 1 PacketSideDataType
+// +gen paramtype av_packet_side_data_name 0 PacketSideDataType
 
 // +gen wrapfunc av_get_profile_name GetProfileName
@@ -250,7 +250,7 @@ type Context struct {
 	DumpSeparator             *uint8
 	CodecWhitelist            *common.CChar
 	Properties                uint32
-	CodedSideData             *C.struct_AVPacketSideData
+	CodedSideData             *PacketSideData
 	NbCodedSideData           int32
 	HwFramesCtx               *avutil.BufferRef
 	SubTextFormat             int32
@@ -273,12 +273,17 @@ type Packet struct {
 	Size                int32
 	StreamIndex         int32
 	Flags               int32
-	SideData            *C.struct_AVPacketSideData
+	SideData            *PacketSideData
 	SideDataElems       int32
 	Duration            int64
 	Pos                 int64
 	ConvergenceDuration int64
 }
+type PacketSideData struct {
+	Data *uint8
+	Size int32
+	Type PacketSideDataType
+}
 type Parameters struct {
 	CodecType          int32
 	CodecID            ID
@@ -0,0 +1,21 @@
+package avcodec
+
+// #include <libavcodec/avcodec.h>
+import "C"
+
+const (
+	CapabilityDR1               = C.AV_CODEC_CAP_DR1
+	CapabilityDelay             = C.AV_CODEC_CAP_DELAY
+	CapabilitySmallLastFrame    = C.AV_CODEC_CAP_SMALL_LAST_FRAME
+	CapabilityExperimental      = C.AV_CODEC_CAP_EXPERIMENTAL
+	CapabilityChannelConf       = C.AV_CODEC_CAP_CHANNEL_CONF
+	CapabilityFrameThreads      = C.AV_CODEC_CAP_FRAME_THREADS
+	CapabilitySliceThreads      = C.AV_CODEC_CAP_SLICE_THREADS
+	CapabilityParamChange       = C.AV_CODEC_CAP_PARAM_CHANGE
+	CapabilityAutoThreads       = C.AV_CODEC_CAP_AUTO_THREADS
+	CapabilityVariableFrameSize = C.AV_CODEC_CAP_VARIABLE_FRAME_SIZE
+	CapabilityAvoidProbing      = C.AV_CODEC_CAP_AVOID_PROBING
+	CapabilityHardware          = C.AV_CODEC_CAP_HARDWARE
+	CapabilityHybrid            = C.AV_CODEC_CAP_HYBRID
+	CapabilityLossless          = C.AV_CODEC_CAP_LOSSLESS
+)
@@ -31,6 +31,12 @@ struct AVPacket;
 
 static void *handle = 0;
 
+static int (*_av_new_packet)(struct AVPacket*, int);
+
+int dyn_av_new_packet(struct AVPacket* p0, int p1) {
+    return _av_new_packet(p0, p1);
+};
+
 static int (*_avcodec_parameters_copy)(struct AVCodecParameters*, struct AVCodecParameters*);
 
 int dyn_avcodec_parameters_copy(struct AVCodecParameters* p0, struct AVCodecParameters* p1) {
@@ -61,6 +67,12 @@ struct AVCodec* dyn_avcodec_find_encoder_by_name(char* p0) {
     return _avcodec_find_encoder_by_name(p0);
 };
 
+static void (*_avcodec_flush_buffers)(struct AVCodecContext*);
+
+void dyn_avcodec_flush_buffers(struct AVCodecContext* p0) {
+    _avcodec_flush_buffers(p0);
+};
+
 static void (*_av_bsf_free)(struct AVBSFContext**);
 
 void dyn_av_bsf_free(struct AVBSFContext** p0) {
@@ -97,6 +109,30 @@ int dyn_av_bsf_init(struct AVBSFContext* p0) {
     return _av_bsf_init(p0);
 };
 
+static int (*_av_codec_is_decoder)(struct AVCodec*);
+
+int dyn_av_codec_is_decoder(struct AVCodec* p0) {
+    return _av_codec_is_decoder(p0);
+};
+
+static int (*_av_codec_is_encoder)(struct AVCodec*);
+
+int dyn_av_codec_is_encoder(struct AVCodec* p0) {
+    return _av_codec_is_encoder(p0);
+};
+
+static struct AVBitStreamFilter* (*_av_bsf_iterate)(void**);
+
+struct AVBitStreamFilter* dyn_av_bsf_iterate(void** p0) {
+    return _av_bsf_iterate(p0);
+};
+
+static struct AVCodec* (*_av_codec_iterate)(void**);
+
+struct AVCodec* dyn_av_codec_iterate(void** p0) {
+    return _av_codec_iterate(p0);
+};
+
 static int (*_av_bsf_alloc)(struct AVBitStreamFilter*, struct AVBSFContext**);
 
 int dyn_av_bsf_alloc(struct AVBitStreamFilter* p0, struct AVBSFContext** p1) {
@@ -115,6 +151,12 @@ struct AVPacket* dyn_av_packet_alloc() {
     return _av_packet_alloc();
 };
 
+static uint8_t* (*_av_packet_new_side_data)(struct AVPacket*, uint32_t, int);
+
+uint8_t* dyn_av_packet_new_side_data(struct AVPacket* p0, uint32_t p1, int p2) {
+    return _av_packet_new_side_data(p0, p1, p2);
+};
+
 static int (*_avcodec_open2)(struct AVCodecContext*, struct AVCodec*, struct AVDictionary**);
 
 int dyn_avcodec_open2(struct AVCodecContext* p0, struct AVCodec* p1, struct AVDictionary** p2) {
@@ -187,12 +229,22 @@ char* dyn_avcodec_get_name(uint32_t p0) {
     return _avcodec_get_name(p0);
 };
 
+static char* (*_av_packet_side_data_name)(uint32_t);
+
+char* dyn_av_packet_side_data_name(uint32_t p0) {
+    return _av_packet_side_data_name(p0);
+};
+
 char *goav_load_avcodec() {
     char *ret;
     handle = dlopen("libavcodec.so", RTLD_NOW | RTLD_GLOBAL);
     if (ret = dlerror()) {
         return ret;
     }
+    _av_new_packet = dlsym(handle, "av_new_packet");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _avcodec_parameters_copy = dlsym(handle, "avcodec_parameters_copy");
     if (ret = dlerror()) {
         return ret;
@@ -213,6 +265,10 @@ char *goav_load_avcodec() {
     if (ret = dlerror()) {
         return ret;
     }
+    _avcodec_flush_buffers = dlsym(handle, "avcodec_flush_buffers");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_bsf_free = dlsym(handle, "av_bsf_free");
     if (ret = dlerror()) {
         return ret;
@@ -237,6 +293,22 @@ char *goav_load_avcodec() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_codec_is_decoder = dlsym(handle, "av_codec_is_decoder");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_codec_is_encoder = dlsym(handle, "av_codec_is_encoder");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_bsf_iterate = dlsym(handle, "av_bsf_iterate");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_codec_iterate = dlsym(handle, "av_codec_iterate");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_bsf_alloc = dlsym(handle, "av_bsf_alloc");
     if (ret = dlerror()) {
         return ret;
@@ -249,6 +321,10 @@ char *goav_load_avcodec() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_packet_new_side_data = dlsym(handle, "av_packet_new_side_data");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _avcodec_open2 = dlsym(handle, "avcodec_open2");
     if (ret = dlerror()) {
         return ret;
@@ -297,6 +373,10 @@ char *goav_load_avcodec() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_packet_side_data_name = dlsym(handle, "av_packet_side_data_name");
+    if (ret = dlerror()) {
+        return ret;
+    }
     return 0;
 }
 */
@@ -322,6 +402,13 @@ func dynamicInit() {
 		panic(initError)
 	}
 }
+func AllocPacketData(p0 *Packet, p1 int32) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	ret := C.dyn_av_new_packet((*C.struct_AVPacket)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func CopyParameters(p0 *Parameters, p1 *Parameters) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -355,6 +442,11 @@ func FindEncoderByName(p0 string) *Codec {
 	}
 	return (*Codec)(unsafe.Pointer(C.dyn_avcodec_find_encoder_by_name(s0)))
 }
+func FlushBuffers(p0 *Context) {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	C.dyn_avcodec_flush_buffers((*C.struct_AVCodecContext)(unsafe.Pointer(p0)))
+}
 func FreeBitstreamFilter(p0 **BitstreamFilterContext) {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -391,6 +483,26 @@ func InitBitstreamFilter(p0 *BitstreamFilterContext) int32 {
 	ret := C.dyn_av_bsf_init((*C.struct_AVBSFContext)(unsafe.Pointer(p0)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func IsDecoder(p0 *Codec) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_codec_is_decoder((*C.struct_AVCodec)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func IsEncoder(p0 *Codec) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_codec_is_encoder((*C.struct_AVCodec)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func IterateBitstreamFilters(p0 *unsafe.Pointer) *BitstreamFilter {
+	dynamicInit()
+	return (*BitstreamFilter)(unsafe.Pointer(C.dyn_av_bsf_iterate(p0)))
+}
+func IterateCodecs(p0 *unsafe.Pointer) *Codec {
+	dynamicInit()
+	return (*Codec)(unsafe.Pointer(C.dyn_av_codec_iterate(p0)))
+}
 func NewBitstreamFilter(p0 *BitstreamFilter, p1 **BitstreamFilterContext) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -407,6 +519,12 @@ func NewPacket() *Packet {
 	dynamicInit()
 	return (*Packet)(unsafe.Pointer(C.dyn_av_packet_alloc()))
 }
+func NewPacketSideData(p0 *Packet, p1 PacketSideDataType, p2 int32) *uint8 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p2)
+	return (*uint8)(unsafe.Pointer(C.dyn_av_packet_new_side_data((*C.struct_AVPacket)(unsafe.Pointer(p0)), (C.uint32_t)(p1), *(*C.int)(unsafe.Pointer(&p2)))))
+}
 func Open(p0 *Context, p1 *Codec, p2 **avutil.Dictionary) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -487,3 +605,7 @@ func getName(p0 ID) *common.CChar {
 	dynamicInit()
 	return (*common.CChar)(unsafe.Pointer(C.dyn_avcodec_get_name((C.uint32_t)(p0))))
 }
+func getPacketSideDataName(p0 PacketSideDataType) *common.CChar {
+	dynamicInit()
+	return (*common.CChar)(unsafe.Pointer(C.dyn_av_packet_side_data_name((C.uint32_t)(p0))))
+}
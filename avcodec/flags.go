@@ -0,0 +1,18 @@
+package avcodec
+
+// #include <libavcodec/avcodec.h>
+import "C"
+
+const (
+	FlagGlobalHeader = C.AV_CODEC_FLAG_GLOBAL_HEADER
+)
+
+const (
+	PacketFlagKey     = C.AV_PKT_FLAG_KEY
+	PacketFlagCorrupt = C.AV_PKT_FLAG_CORRUPT
+	PacketFlagDiscard = C.AV_PKT_FLAG_DISCARD
+)
+
+const (
+	InputBufferPaddingSize = C.AV_INPUT_BUFFER_PADDING_SIZE
+)
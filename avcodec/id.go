@@ -6,6 +6,7 @@ import "C"
 type ID C.enum_AVCodecID
 
 const (
+	None = ID(C.AV_CODEC_ID_NONE)
 	AV1  = ID(C.AV_CODEC_ID_AV1)
 	HEVC = ID(C.AV_CODEC_ID_HEVC)
 	H264 = ID(C.AV_CODEC_ID_H264)
@@ -0,0 +1,26 @@
+package avcodec
+
+// #include <libavcodec/packet.h>
+import "C"
+
+type PacketSideDataType C.enum_AVPacketSideDataType
+
+const (
+	PacketSideDataNewExtradata             = PacketSideDataType(C.AV_PKT_DATA_NEW_EXTRADATA)
+	PacketSideDataParamChange              = PacketSideDataType(C.AV_PKT_DATA_PARAM_CHANGE)
+	PacketSideDataReplayGain               = PacketSideDataType(C.AV_PKT_DATA_REPLAYGAIN)
+	PacketSideDataDisplayMatrix            = PacketSideDataType(C.AV_PKT_DATA_DISPLAYMATRIX)
+	PacketSideDataStereo3D                 = PacketSideDataType(C.AV_PKT_DATA_STEREO3D)
+	PacketSideDataSkipSamples              = PacketSideDataType(C.AV_PKT_DATA_SKIP_SAMPLES)
+	PacketSideDataStringsMetadata          = PacketSideDataType(C.AV_PKT_DATA_STRINGS_METADATA)
+	PacketSideDataMetadataUpdate           = PacketSideDataType(C.AV_PKT_DATA_METADATA_UPDATE)
+	PacketSideDataMasteringDisplayMetadata = PacketSideDataType(C.AV_PKT_DATA_MASTERING_DISPLAY_METADATA)
+	PacketSideDataSpherical                = PacketSideDataType(C.AV_PKT_DATA_SPHERICAL)
+	PacketSideDataContentLightLevel        = PacketSideDataType(C.AV_PKT_DATA_CONTENT_LIGHT_LEVEL)
+	PacketSideDataA53CC                    = PacketSideDataType(C.AV_PKT_DATA_A53_CC)
+	PacketSideDataICCProfile               = PacketSideDataType(C.AV_PKT_DATA_ICC_PROFILE)
+)
+
+func (t PacketSideDataType) String() string {
+	return getPacketSideDataName(t).String()
+}
@@ -18,6 +18,12 @@ import (
 */
 import "C"
 
+func AllocPacketData(p0 *Packet, p1 int32) int32 {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	ret := C.av_new_packet((*C.struct_AVPacket)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func CopyParameters(p0 *Parameters, p1 *Parameters) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -46,6 +52,10 @@ func FindEncoderByName(p0 string) *Codec {
 	}
 	return (*Codec)(unsafe.Pointer(C.avcodec_find_encoder_by_name(s0)))
 }
+func FlushBuffers(p0 *Context) {
+	defer runtime.KeepAlive(p0)
+	C.avcodec_flush_buffers((*C.struct_AVCodecContext)(unsafe.Pointer(p0)))
+}
 func FreeBitstreamFilter(p0 **BitstreamFilterContext) {
 	defer runtime.KeepAlive(p0)
 	C.av_bsf_free((**C.struct_AVBSFContext)(unsafe.Pointer(p0)))
@@ -76,6 +86,22 @@ func InitBitstreamFilter(p0 *BitstreamFilterContext) int32 {
 	ret := C.av_bsf_init((*C.struct_AVBSFContext)(unsafe.Pointer(p0)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func IsDecoder(p0 *Codec) int32 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_codec_is_decoder((*C.struct_AVCodec)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func IsEncoder(p0 *Codec) int32 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_codec_is_encoder((*C.struct_AVCodec)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func IterateBitstreamFilters(p0 *unsafe.Pointer) *BitstreamFilter {
+	return (*BitstreamFilter)(unsafe.Pointer(C.av_bsf_iterate(p0)))
+}
+func IterateCodecs(p0 *unsafe.Pointer) *Codec {
+	return (*Codec)(unsafe.Pointer(C.av_codec_iterate(p0)))
+}
 func NewBitstreamFilter(p0 *BitstreamFilter, p1 **BitstreamFilterContext) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -89,6 +115,11 @@ func NewContext(p0 *Codec) *Context {
 func NewPacket() *Packet {
 	return (*Packet)(unsafe.Pointer(C.av_packet_alloc()))
 }
+func NewPacketSideData(p0 *Packet, p1 PacketSideDataType, p2 int32) *uint8 {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p2)
+	return (*uint8)(unsafe.Pointer(C.av_packet_new_side_data((*C.struct_AVPacket)(unsafe.Pointer(p0)), (uint32)(p1), *(*C.int)(unsafe.Pointer(&p2)))))
+}
 func Open(p0 *Context, p1 *Codec, p2 **avutil.Dictionary) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -157,3 +188,6 @@ func UnrefPacket(p0 *Packet) {
 func getName(p0 ID) *common.CChar {
 	return (*common.CChar)(unsafe.Pointer(C.avcodec_get_name((uint32)(p0))))
 }
+func getPacketSideDataName(p0 PacketSideDataType) *common.CChar {
+	return (*common.CChar)(unsafe.Pointer(C.av_packet_side_data_name((uint32)(p0))))
+}
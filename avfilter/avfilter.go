@@ -24,6 +24,10 @@ import "C"
 // +gen wrapfunc avfilter_graph_create_filter CreateFilterGraph
 // +gen wrapfunc avfilter_link Link
 // +gen wrapfunc avfilter_get_by_name GetByName
+// +gen wrapfunc av_filter_iterate IterateFilters
+// +gen wrapfunc avfilter_pad_count CountPads
+// +gen wrapfunc avfilter_pad_get_name GetPadName
+// +gen wrapfunc avfilter_pad_get_type GetPadType
 // +gen wrapfunc avfilter_inout_free FreeInOut
 // +gen wrapfunc avfilter_graph_parse2 ParseGraph
 // +gen wrapfunc avfilter_graph_config ConfigGraph
@@ -33,3 +37,11 @@ import "C"
 // +gen wrapfunc av_buffersrc_write_frame WriteBufferSourceFrame
 
 // +gen wrapfunc av_buffersink_get_frame GetBufferSinkFrame
+// +gen wrapfunc av_buffersink_set_frame_size SetBufferSinkFrameSize
+// +gen wrapfunc av_buffersink_get_time_base GetBufferSinkTimeBase
+// +gen wrapfunc av_buffersink_get_frame_rate GetBufferSinkFrameRate
+// +gen wrapfunc av_buffersink_get_format GetBufferSinkFormat
+// +gen wrapfunc av_buffersink_get_w GetBufferSinkWidth
+// +gen wrapfunc av_buffersink_get_h GetBufferSinkHeight
+// +gen wrapfunc av_buffersink_get_sample_rate GetBufferSinkSampleRate
+// +gen wrapfunc av_buffersink_get_channel_layout GetBufferSinkChannelLayout
@@ -7,6 +7,7 @@ package avfilter
 import (
 	errors "github.com/pkg/errors"
 	avutil "github.com/ssttevee/go-av/avutil"
+	common "github.com/ssttevee/go-av/internal/common"
 	"runtime"
 	"sync"
 	"unsafe"
@@ -24,7 +25,9 @@ struct AVFilter;
 struct AVFilterContext;
 struct AVFilterGraph;
 struct AVFilterInOut;
+struct AVFilterPad;
 struct AVFrame;
+struct AVRational{};
 
 static void *handle = 0;
 
@@ -34,6 +37,12 @@ int dyn_avfilter_graph_config(struct AVFilterGraph* p0, void* p1) {
     return _avfilter_graph_config(p0, p1);
 };
 
+static int (*_avfilter_pad_count)(struct AVFilterPad*);
+
+int dyn_avfilter_pad_count(struct AVFilterPad* p0) {
+    return _avfilter_pad_count(p0);
+};
+
 static int (*_avfilter_graph_create_filter)(struct AVFilterContext**, struct AVFilter*, char*, char*, void*, struct AVFilterGraph*);
 
 int dyn_avfilter_graph_create_filter(struct AVFilterContext** p0, struct AVFilter* p1, char* p2, char* p3, void* p4, struct AVFilterGraph* p5) {
@@ -52,18 +61,78 @@ void dyn_avfilter_inout_free(struct AVFilterInOut** p0) {
     _avfilter_inout_free(p0);
 };
 
+static uint64_t (*_av_buffersink_get_channel_layout)(struct AVFilterContext*);
+
+uint64_t dyn_av_buffersink_get_channel_layout(struct AVFilterContext* p0) {
+    return _av_buffersink_get_channel_layout(p0);
+};
+
+static int (*_av_buffersink_get_format)(struct AVFilterContext*);
+
+int dyn_av_buffersink_get_format(struct AVFilterContext* p0) {
+    return _av_buffersink_get_format(p0);
+};
+
 static int (*_av_buffersink_get_frame)(struct AVFilterContext*, struct AVFrame*);
 
 int dyn_av_buffersink_get_frame(struct AVFilterContext* p0, struct AVFrame* p1) {
     return _av_buffersink_get_frame(p0, p1);
 };
 
+static struct AVRational (*_av_buffersink_get_frame_rate)(struct AVFilterContext*);
+
+struct AVRational dyn_av_buffersink_get_frame_rate(struct AVFilterContext* p0) {
+    return _av_buffersink_get_frame_rate(p0);
+};
+
+static int (*_av_buffersink_get_h)(struct AVFilterContext*);
+
+int dyn_av_buffersink_get_h(struct AVFilterContext* p0) {
+    return _av_buffersink_get_h(p0);
+};
+
+static int (*_av_buffersink_get_sample_rate)(struct AVFilterContext*);
+
+int dyn_av_buffersink_get_sample_rate(struct AVFilterContext* p0) {
+    return _av_buffersink_get_sample_rate(p0);
+};
+
+static struct AVRational (*_av_buffersink_get_time_base)(struct AVFilterContext*);
+
+struct AVRational dyn_av_buffersink_get_time_base(struct AVFilterContext* p0) {
+    return _av_buffersink_get_time_base(p0);
+};
+
+static int (*_av_buffersink_get_w)(struct AVFilterContext*);
+
+int dyn_av_buffersink_get_w(struct AVFilterContext* p0) {
+    return _av_buffersink_get_w(p0);
+};
+
 static struct AVFilter* (*_avfilter_get_by_name)(char*);
 
 struct AVFilter* dyn_avfilter_get_by_name(char* p0) {
     return _avfilter_get_by_name(p0);
 };
 
+static char* (*_avfilter_pad_get_name)(struct AVFilterPad*, int);
+
+char* dyn_avfilter_pad_get_name(struct AVFilterPad* p0, int p1) {
+    return _avfilter_pad_get_name(p0, p1);
+};
+
+static uint32_t (*_avfilter_pad_get_type)(struct AVFilterPad*, int);
+
+uint32_t dyn_avfilter_pad_get_type(struct AVFilterPad* p0, int p1) {
+    return _avfilter_pad_get_type(p0, p1);
+};
+
+static struct AVFilter* (*_av_filter_iterate)(void**);
+
+struct AVFilter* dyn_av_filter_iterate(void** p0) {
+    return _av_filter_iterate(p0);
+};
+
 static int (*_avfilter_link)(struct AVFilterContext*, uint, struct AVFilterContext*, uint);
 
 int dyn_avfilter_link(struct AVFilterContext* p0, uint p1, struct AVFilterContext* p2, uint p3) {
@@ -88,6 +157,12 @@ int dyn_avfilter_graph_parse2(struct AVFilterGraph* p0, char* p1, struct AVFilte
     return _avfilter_graph_parse2(p0, p1, p2, p3);
 };
 
+static void (*_av_buffersink_set_frame_size)(struct AVFilterContext*, uint);
+
+void dyn_av_buffersink_set_frame_size(struct AVFilterContext* p0, uint p1) {
+    _av_buffersink_set_frame_size(p0, p1);
+};
+
 static int (*_av_buffersrc_parameters_set)(struct AVFilterContext*, struct AVBufferSrcParameters*);
 
 int dyn_av_buffersrc_parameters_set(struct AVFilterContext* p0, struct AVBufferSrcParameters* p1) {
@@ -110,6 +185,10 @@ char *goav_load_avfilter() {
     if (ret = dlerror()) {
         return ret;
     }
+    _avfilter_pad_count = dlsym(handle, "avfilter_pad_count");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _avfilter_graph_create_filter = dlsym(handle, "avfilter_graph_create_filter");
     if (ret = dlerror()) {
         return ret;
@@ -122,14 +201,54 @@ char *goav_load_avfilter() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_buffersink_get_channel_layout = dlsym(handle, "av_buffersink_get_channel_layout");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_buffersink_get_format = dlsym(handle, "av_buffersink_get_format");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_buffersink_get_frame = dlsym(handle, "av_buffersink_get_frame");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_buffersink_get_frame_rate = dlsym(handle, "av_buffersink_get_frame_rate");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_buffersink_get_h = dlsym(handle, "av_buffersink_get_h");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_buffersink_get_sample_rate = dlsym(handle, "av_buffersink_get_sample_rate");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_buffersink_get_time_base = dlsym(handle, "av_buffersink_get_time_base");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_buffersink_get_w = dlsym(handle, "av_buffersink_get_w");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _avfilter_get_by_name = dlsym(handle, "avfilter_get_by_name");
     if (ret = dlerror()) {
         return ret;
     }
+    _avfilter_pad_get_name = dlsym(handle, "avfilter_pad_get_name");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _avfilter_pad_get_type = dlsym(handle, "avfilter_pad_get_type");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_filter_iterate = dlsym(handle, "av_filter_iterate");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _avfilter_link = dlsym(handle, "avfilter_link");
     if (ret = dlerror()) {
         return ret;
@@ -146,6 +265,10 @@ char *goav_load_avfilter() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_buffersink_set_frame_size = dlsym(handle, "av_buffersink_set_frame_size");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_buffersrc_parameters_set = dlsym(handle, "av_buffersrc_parameters_set");
     if (ret = dlerror()) {
         return ret;
@@ -185,6 +308,11 @@ func ConfigGraph(p0 *Graph, p1 unsafe.Pointer) int32 {
 	ret := C.dyn_avfilter_graph_config((*C.struct_AVFilterGraph)(unsafe.Pointer(p0)), p1)
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func CountPads(p0 *C.struct_AVFilterPad) int32 {
+	dynamicInit()
+	ret := C.dyn_avfilter_pad_count(p0)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func CreateFilterGraph(p0 **Context, p1 *Filter, p2 string, p3 string, p4 unsafe.Pointer, p5 *Graph) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -213,6 +341,18 @@ func FreeInOut(p0 **InOut) {
 	defer runtime.KeepAlive(p0)
 	C.dyn_avfilter_inout_free((**C.struct_AVFilterInOut)(unsafe.Pointer(p0)))
 }
+func GetBufferSinkChannelLayout(p0 *Context) uint64 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_buffersink_get_channel_layout((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*uint64)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkFormat(p0 *Context) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_buffersink_get_format((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func GetBufferSinkFrame(p0 *Context, p1 *avutil.Frame) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -220,6 +360,36 @@ func GetBufferSinkFrame(p0 *Context, p1 *avutil.Frame) int32 {
 	ret := C.dyn_av_buffersink_get_frame((*C.struct_AVFilterContext)(unsafe.Pointer(p0)), (*C.struct_AVFrame)(unsafe.Pointer(p1)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func GetBufferSinkFrameRate(p0 *Context) avutil.Rational {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_buffersink_get_frame_rate((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*avutil.Rational)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkHeight(p0 *Context) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_buffersink_get_h((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkSampleRate(p0 *Context) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_buffersink_get_sample_rate((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkTimeBase(p0 *Context) avutil.Rational {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_buffersink_get_time_base((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*avutil.Rational)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkWidth(p0 *Context) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_buffersink_get_w((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func GetByName(p0 string) *Filter {
 	dynamicInit()
 	var s0 *C.char
@@ -229,6 +399,21 @@ func GetByName(p0 string) *Filter {
 	}
 	return (*Filter)(unsafe.Pointer(C.dyn_avfilter_get_by_name(s0)))
 }
+func GetPadName(p0 *C.struct_AVFilterPad, p1 int32) *common.CChar {
+	dynamicInit()
+	defer runtime.KeepAlive(p1)
+	return (*common.CChar)(unsafe.Pointer(C.dyn_avfilter_pad_get_name(p0, *(*C.int)(unsafe.Pointer(&p1)))))
+}
+func GetPadType(p0 *C.struct_AVFilterPad, p1 int32) uint32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p1)
+	ret := C.dyn_avfilter_pad_get_type(p0, *(*C.int)(unsafe.Pointer(&p1)))
+	return *(*uint32)(unsafe.Pointer(&ret))
+}
+func IterateFilters(p0 *unsafe.Pointer) *Filter {
+	dynamicInit()
+	return (*Filter)(unsafe.Pointer(C.dyn_av_filter_iterate(p0)))
+}
 func Link(p0 *Context, p1 uint32, p2 *Context, p3 uint32) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -259,6 +444,12 @@ func ParseGraph(p0 *Graph, p1 string, p2 **InOut, p3 **InOut) int32 {
 	ret := C.dyn_avfilter_graph_parse2((*C.struct_AVFilterGraph)(unsafe.Pointer(p0)), s1, (**C.struct_AVFilterInOut)(unsafe.Pointer(p2)), (**C.struct_AVFilterInOut)(unsafe.Pointer(p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetBufferSinkFrameSize(p0 *Context, p1 uint32) {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	C.dyn_av_buffersink_set_frame_size((*C.struct_AVFilterContext)(unsafe.Pointer(p0)), *(*C.uint)(unsafe.Pointer(&p1)))
+}
 func SetBufferSourceParameters(p0 *Context, p1 *BufferSourceParameters) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
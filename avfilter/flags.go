@@ -0,0 +1,12 @@
+package avfilter
+
+// #include <libavfilter/avfilter.h>
+import "C"
+
+const (
+	FlagDynamicInputs           = C.AVFILTER_FLAG_DYNAMIC_INPUTS
+	FlagDynamicOutputs          = C.AVFILTER_FLAG_DYNAMIC_OUTPUTS
+	FlagSliceThreads            = C.AVFILTER_FLAG_SLICE_THREADS
+	FlagSupportTimelineGeneric  = C.AVFILTER_FLAG_SUPPORT_TIMELINE_GENERIC
+	FlagSupportTimelineInternal = C.AVFILTER_FLAG_SUPPORT_TIMELINE_INTERNAL
+)
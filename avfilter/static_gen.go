@@ -6,6 +6,7 @@ package avfilter
 
 import (
 	avutil "github.com/ssttevee/go-av/avutil"
+	common "github.com/ssttevee/go-av/internal/common"
 	"runtime"
 	"unsafe"
 )
@@ -21,6 +22,10 @@ func ConfigGraph(p0 *Graph, p1 unsafe.Pointer) int32 {
 	ret := C.avfilter_graph_config((*C.struct_AVFilterGraph)(unsafe.Pointer(p0)), p1)
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func CountPads(p0 *C.struct_AVFilterPad) int32 {
+	ret := C.avfilter_pad_count(p0)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func CreateFilterGraph(p0 **Context, p1 *Filter, p2 string, p3 string, p4 unsafe.Pointer, p5 *Graph) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -46,12 +51,47 @@ func FreeInOut(p0 **InOut) {
 	defer runtime.KeepAlive(p0)
 	C.avfilter_inout_free((**C.struct_AVFilterInOut)(unsafe.Pointer(p0)))
 }
+func GetBufferSinkChannelLayout(p0 *Context) uint64 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_buffersink_get_channel_layout((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*uint64)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkFormat(p0 *Context) int32 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_buffersink_get_format((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func GetBufferSinkFrame(p0 *Context, p1 *avutil.Frame) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
 	ret := C.av_buffersink_get_frame((*C.struct_AVFilterContext)(unsafe.Pointer(p0)), (*C.struct_AVFrame)(unsafe.Pointer(p1)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func GetBufferSinkFrameRate(p0 *Context) avutil.Rational {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_buffersink_get_frame_rate((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*avutil.Rational)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkHeight(p0 *Context) int32 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_buffersink_get_h((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkSampleRate(p0 *Context) int32 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_buffersink_get_sample_rate((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkTimeBase(p0 *Context) avutil.Rational {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_buffersink_get_time_base((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*avutil.Rational)(unsafe.Pointer(&ret))
+}
+func GetBufferSinkWidth(p0 *Context) int32 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_buffersink_get_w((*C.struct_AVFilterContext)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func GetByName(p0 string) *Filter {
 	var s0 *C.char
 	if p0 != "" {
@@ -60,6 +100,18 @@ func GetByName(p0 string) *Filter {
 	}
 	return (*Filter)(unsafe.Pointer(C.avfilter_get_by_name(s0)))
 }
+func GetPadName(p0 *C.struct_AVFilterPad, p1 int32) *common.CChar {
+	defer runtime.KeepAlive(p1)
+	return (*common.CChar)(unsafe.Pointer(C.avfilter_pad_get_name(p0, *(*C.int)(unsafe.Pointer(&p1)))))
+}
+func GetPadType(p0 *C.struct_AVFilterPad, p1 int32) uint32 {
+	defer runtime.KeepAlive(p1)
+	ret := C.avfilter_pad_get_type(p0, *(*C.int)(unsafe.Pointer(&p1)))
+	return *(*uint32)(unsafe.Pointer(&ret))
+}
+func IterateFilters(p0 *unsafe.Pointer) *Filter {
+	return (*Filter)(unsafe.Pointer(C.av_filter_iterate(p0)))
+}
 func Link(p0 *Context, p1 uint32, p2 *Context, p3 uint32) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -86,6 +138,11 @@ func ParseGraph(p0 *Graph, p1 string, p2 **InOut, p3 **InOut) int32 {
 	ret := C.avfilter_graph_parse2((*C.struct_AVFilterGraph)(unsafe.Pointer(p0)), s1, (**C.struct_AVFilterInOut)(unsafe.Pointer(p2)), (**C.struct_AVFilterInOut)(unsafe.Pointer(p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetBufferSinkFrameSize(p0 *Context, p1 uint32) {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	C.av_buffersink_set_frame_size((*C.struct_AVFilterContext)(unsafe.Pointer(p0)), *(*C.uint)(unsafe.Pointer(&p1)))
+}
 func SetBufferSourceParameters(p0 *Context, p1 *BufferSourceParameters) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -13,12 +13,19 @@ import "C"
 // +gen convtype struct_AVFrame github.com/ssttevee/go-av/avutil.Frame
 // +gen convtype struct_AVClass github.com/ssttevee/go-av/avutil.Class
 
+// +gen convtype struct_AVChapter Chapter
 // +gen convtype struct_AVInputFormat InputFormat
+// +gen convtype struct_AVOutputFormat OutputFormat
 // +gen convtype struct_AVStream Stream
 // +gen convtype struct_AVFormatContext Context
 // +gen convtype struct_AVIOContext IOContext
+// +gen convtype struct_AVProbeData ProbeData
 
 // +gen fieldtype struct_AVStream codecpar *github.com/ssttevee/go-av/avcodec.Parameters
+// +gen fieldtype struct_AVOutputFormat audio_codec github.com/ssttevee/go-av/avcodec.ID
+// +gen fieldtype struct_AVOutputFormat video_codec github.com/ssttevee/go-av/avcodec.ID
+// +gen fieldtype struct_AVOutputFormat subtitle_codec github.com/ssttevee/go-av/avcodec.ID
+// +gen fieldtype struct_AVOutputFormat data_codec github.com/ssttevee/go-av/avcodec.ID
 
 // +gen wrapfunc avformat_alloc_context NewContext
 // +gen wrapfunc avformat_free_context FreeContext
@@ -29,8 +36,10 @@ import "C"
 // +gen wrapfunc avformat_alloc_output_context2 NewOutputContext
 // +gen wrapfunc avformat_write_header WriteHeader
 // +gen wrapfunc avformat_seek_file SeekFile
+// +gen wrapfunc avformat_query_codec QueryCodec
 
 // +gen wrapfunc avio_open OpenIO
+// +gen wrapfunc avio_open2 OpenIO2
 // +gen wrapfunc avio_close CloseIO
 // +gen wrapfunc avio_alloc_context NewIOContext
 // +gen wrapfunc avio_context_free FreeIOContext
@@ -40,9 +49,15 @@ import "C"
 // +gen wrapfunc av_read_frame ReadFrame
 // +gen wrapfunc av_interleaved_write_frame WriteInterleavedFrame
 // +gen wrapfunc av_write_trailer WriteTrailer
+// +gen wrapfunc av_find_input_format FindInputFormat
+// +gen wrapfunc av_probe_input_format3 ProbeInputFormat
+// +gen wrapfunc av_guess_format GuessFormat
+// +gen wrapfunc av_demuxer_iterate IterateDemuxers
+// +gen wrapfunc av_muxer_iterate IterateMuxers
 
 // +gen paramtype avio_alloc_context 4 unsafe.Pointer
 // +gen paramtype avio_alloc_context 5 unsafe.Pointer
 // +gen paramtype avio_alloc_context 6 unsafe.Pointer
 
 // +gen paramtype av_find_best_stream 1 github.com/ssttevee/go-av/avutil.MediaType
+// +gen paramtype avformat_query_codec 1 github.com/ssttevee/go-av/avcodec.ID
@@ -14,10 +14,18 @@ import (
 */
 import "C"
 
+type Chapter struct {
+	ID       int32
+	TimeBase avutil.Rational
+	_        [4]byte
+	Start    int64
+	End      int64
+	Metadata *avutil.Dictionary
+}
 type Context struct {
 	AvClass                     *avutil.Class
 	Iformat                     *InputFormat
-	Oformat                     *C.struct_AVOutputFormat
+	Oformat                     *OutputFormat
 	PrivData                    unsafe.Pointer
 	Pb                          *IOContext
 	CtxFlags                    int32
@@ -43,7 +51,7 @@ type Context struct {
 	MaxIndexSize                uint32
 	MaxPictureBuffer            uint32
 	NbChapters                  uint32
-	Chapters                    **C.struct_AVChapter
+	Chapters                    **Chapter
 	Metadata                    *avutil.Dictionary
 	StartTimeRealtime           int64
 	FpsProbeSize                int32
@@ -156,6 +164,44 @@ type InputFormat struct {
 	CreateDeviceCapabilities *[0]byte
 	FreeDeviceCapabilities   *[0]byte
 }
+type OutputFormat struct {
+	Name                     *common.CChar
+	LongName                 *common.CChar
+	MimeType                 *common.CChar
+	Extensions               *common.CChar
+	AudioCodec               avcodec.ID
+	VideoCodec               avcodec.ID
+	SubtitleCodec            avcodec.ID
+	Flags                    int32
+	CodecTag                 **C.struct_AVCodecTag
+	PrivClass                *avutil.Class
+	Next                     *OutputFormat
+	PrivDataSize             int32
+	_                        [4]byte
+	WriteHeader              *[0]byte
+	WritePacket              *[0]byte
+	WriteTrailer             *[0]byte
+	InterleavePacket         *[0]byte
+	QueryCodec               *[0]byte
+	GetOutputTimestamp       *[0]byte
+	ControlMessage           *[0]byte
+	WriteUncodedFrame        *[0]byte
+	GetDeviceList            *[0]byte
+	CreateDeviceCapabilities *[0]byte
+	FreeDeviceCapabilities   *[0]byte
+	DataCodec                avcodec.ID
+	_                        [4]byte
+	Init                     *[0]byte
+	Deinit                   *[0]byte
+	CheckBitstream           *[0]byte
+}
+type ProbeData struct {
+	Filename *common.CChar
+	Buf      *byte
+	BufSize  int32
+	_        [4]byte
+	MimeType *common.CChar
+}
 type Stream struct {
 	Index                           int32
 	ID                              int32
@@ -188,7 +234,7 @@ type Stream struct {
 	NeedParsing                     uint32
 	Parser                          *C.struct_AVCodecParserContext
 	LastInPacketBuffer              *C.struct_AVPacketList
-	ProbeData                       C.struct_AVProbeData
+	ProbeData                       ProbeData
 	PtsBuffer                       [17]int64
 	IndexEntries                    *C.struct_AVIndexEntry
 	NbIndexEntries                  int32
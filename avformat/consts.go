@@ -0,0 +1,8 @@
+package avformat
+
+// #include <libavformat/avformat.h>
+import "C"
+
+const (
+	ProbePaddingSize = C.AVPROBE_PADDING_SIZE
+)
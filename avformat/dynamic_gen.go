@@ -25,9 +25,11 @@ struct AVDictionary;
 struct AVFormatContext;
 struct AVFrame;
 struct AVIOContext;
+struct AVIOInterruptCB;
 struct AVInputFormat;
 struct AVOutputFormat;
 struct AVPacket;
+struct AVProbeData;
 struct AVRational{};
 struct AVStream;
 
@@ -51,6 +53,12 @@ int dyn_av_find_best_stream(struct AVFormatContext* p0, int32_t p1, int p2, int
     return _av_find_best_stream(p0, p1, p2, p3, p4, p5);
 };
 
+static struct AVInputFormat* (*_av_find_input_format)(char*);
+
+struct AVInputFormat* dyn_av_find_input_format(char* p0) {
+    return _av_find_input_format(p0);
+};
+
 static int (*_avformat_find_stream_info)(struct AVFormatContext*, struct AVDictionary**);
 
 int dyn_avformat_find_stream_info(struct AVFormatContext* p0, struct AVDictionary** p1) {
@@ -69,12 +77,30 @@ void dyn_avio_context_free(struct AVIOContext** p0) {
     _avio_context_free(p0);
 };
 
+static struct AVOutputFormat* (*_av_guess_format)(char*, char*, char*);
+
+struct AVOutputFormat* dyn_av_guess_format(char* p0, char* p1, char* p2) {
+    return _av_guess_format(p0, p1, p2);
+};
+
 static struct AVRational (*_av_guess_frame_rate)(struct AVFormatContext*, struct AVStream*, struct AVFrame*);
 
 struct AVRational dyn_av_guess_frame_rate(struct AVFormatContext* p0, struct AVStream* p1, struct AVFrame* p2) {
     return _av_guess_frame_rate(p0, p1, p2);
 };
 
+static struct AVInputFormat* (*_av_demuxer_iterate)(void**);
+
+struct AVInputFormat* dyn_av_demuxer_iterate(void** p0) {
+    return _av_demuxer_iterate(p0);
+};
+
+static struct AVOutputFormat* (*_av_muxer_iterate)(void**);
+
+struct AVOutputFormat* dyn_av_muxer_iterate(void** p0) {
+    return _av_muxer_iterate(p0);
+};
+
 static struct AVFormatContext* (*_avformat_alloc_context)();
 
 struct AVFormatContext* dyn_avformat_alloc_context() {
@@ -105,12 +131,30 @@ int dyn_avio_open(struct AVIOContext** p0, char* p1, int p2) {
     return _avio_open(p0, p1, p2);
 };
 
+static int (*_avio_open2)(struct AVIOContext**, char*, int, struct AVIOInterruptCB*, struct AVDictionary**);
+
+int dyn_avio_open2(struct AVIOContext** p0, char* p1, int p2, struct AVIOInterruptCB* p3, struct AVDictionary** p4) {
+    return _avio_open2(p0, p1, p2, p3, p4);
+};
+
 static int (*_avformat_open_input)(struct AVFormatContext**, char*, struct AVInputFormat*, struct AVDictionary**);
 
 int dyn_avformat_open_input(struct AVFormatContext** p0, char* p1, struct AVInputFormat* p2, struct AVDictionary** p3) {
     return _avformat_open_input(p0, p1, p2, p3);
 };
 
+static struct AVInputFormat* (*_av_probe_input_format3)(struct AVProbeData*, int, int*);
+
+struct AVInputFormat* dyn_av_probe_input_format3(struct AVProbeData* p0, int p1, int* p2) {
+    return _av_probe_input_format3(p0, p1, p2);
+};
+
+static int (*_avformat_query_codec)(struct AVOutputFormat*, uint32_t, int);
+
+int dyn_avformat_query_codec(struct AVOutputFormat* p0, uint32_t p1, int p2) {
+    return _avformat_query_codec(p0, p1, p2);
+};
+
 static int (*_av_read_frame)(struct AVFormatContext*, struct AVPacket*);
 
 int dyn_av_read_frame(struct AVFormatContext* p0, struct AVPacket* p1) {
@@ -159,6 +203,10 @@ char *goav_load_avformat() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_find_input_format = dlsym(handle, "av_find_input_format");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _avformat_find_stream_info = dlsym(handle, "avformat_find_stream_info");
     if (ret = dlerror()) {
         return ret;
@@ -171,10 +219,22 @@ char *goav_load_avformat() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_guess_format = dlsym(handle, "av_guess_format");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_guess_frame_rate = dlsym(handle, "av_guess_frame_rate");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_demuxer_iterate = dlsym(handle, "av_demuxer_iterate");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_muxer_iterate = dlsym(handle, "av_muxer_iterate");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _avformat_alloc_context = dlsym(handle, "avformat_alloc_context");
     if (ret = dlerror()) {
         return ret;
@@ -195,10 +255,22 @@ char *goav_load_avformat() {
     if (ret = dlerror()) {
         return ret;
     }
+    _avio_open2 = dlsym(handle, "avio_open2");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _avformat_open_input = dlsym(handle, "avformat_open_input");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_probe_input_format3 = dlsym(handle, "av_probe_input_format3");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _avformat_query_codec = dlsym(handle, "avformat_query_codec");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_read_frame = dlsym(handle, "av_read_frame");
     if (ret = dlerror()) {
         return ret;
@@ -265,6 +337,15 @@ func FindBestStream(p0 *Context, p1 avutil.MediaType, p2 int32, p3 int32, p4 **a
 	ret := C.dyn_av_find_best_stream((*C.struct_AVFormatContext)(unsafe.Pointer(p0)), (C.int32_t)(p1), *(*C.int)(unsafe.Pointer(&p2)), *(*C.int)(unsafe.Pointer(&p3)), (**C.struct_AVCodec)(unsafe.Pointer(p4)), *(*C.int)(unsafe.Pointer(&p5)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func FindInputFormat(p0 string) *InputFormat {
+	dynamicInit()
+	var s0 *C.char
+	if p0 != "" {
+		s0 = C.CString(p0)
+		defer C.free(unsafe.Pointer(s0))
+	}
+	return (*InputFormat)(unsafe.Pointer(C.dyn_av_find_input_format(s0)))
+}
 func FindStreamInfo(p0 *Context, p1 **avutil.Dictionary) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -282,6 +363,25 @@ func FreeIOContext(p0 **IOContext) {
 	defer runtime.KeepAlive(p0)
 	C.dyn_avio_context_free((**C.struct_AVIOContext)(unsafe.Pointer(p0)))
 }
+func GuessFormat(p0 string, p1 string, p2 string) *OutputFormat {
+	dynamicInit()
+	var s0 *C.char
+	if p0 != "" {
+		s0 = C.CString(p0)
+		defer C.free(unsafe.Pointer(s0))
+	}
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	var s2 *C.char
+	if p2 != "" {
+		s2 = C.CString(p2)
+		defer C.free(unsafe.Pointer(s2))
+	}
+	return (*OutputFormat)(unsafe.Pointer(C.dyn_av_guess_format(s0, s1, s2)))
+}
 func GuessFrameRate(p0 *Context, p1 *Stream, p2 *avutil.Frame) avutil.Rational {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -290,6 +390,14 @@ func GuessFrameRate(p0 *Context, p1 *Stream, p2 *avutil.Frame) avutil.Rational {
 	ret := C.dyn_av_guess_frame_rate((*C.struct_AVFormatContext)(unsafe.Pointer(p0)), (*C.struct_AVStream)(unsafe.Pointer(p1)), (*C.struct_AVFrame)(unsafe.Pointer(p2)))
 	return *(*avutil.Rational)(unsafe.Pointer(&ret))
 }
+func IterateDemuxers(p0 *unsafe.Pointer) *InputFormat {
+	dynamicInit()
+	return (*InputFormat)(unsafe.Pointer(C.dyn_av_demuxer_iterate(p0)))
+}
+func IterateMuxers(p0 *unsafe.Pointer) *OutputFormat {
+	dynamicInit()
+	return (*OutputFormat)(unsafe.Pointer(C.dyn_av_muxer_iterate(p0)))
+}
 func NewContext() *Context {
 	dynamicInit()
 	return (*Context)(unsafe.Pointer(C.dyn_avformat_alloc_context()))
@@ -301,9 +409,10 @@ func NewIOContext(p0 *byte, p1 int32, p2 int32, p3 unsafe.Pointer, p4 unsafe.Poi
 	defer runtime.KeepAlive(p2)
 	return (*IOContext)(unsafe.Pointer(C.dyn_avio_alloc_context((*C.uchar)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)), *(*C.int)(unsafe.Pointer(&p2)), p3, (*[0]byte)(p4), (*[0]byte)(p5), (*[0]byte)(p6))))
 }
-func NewOutputContext(p0 **Context, p1 *C.struct_AVOutputFormat, p2 string, p3 string) int32 {
+func NewOutputContext(p0 **Context, p1 *OutputFormat, p2 string, p3 string) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
 	var s2 *C.char
 	if p2 != "" {
 		s2 = C.CString(p2)
@@ -314,7 +423,7 @@ func NewOutputContext(p0 **Context, p1 *C.struct_AVOutputFormat, p2 string, p3 s
 		s3 = C.CString(p3)
 		defer C.free(unsafe.Pointer(s3))
 	}
-	ret := C.dyn_avformat_alloc_output_context2((**C.struct_AVFormatContext)(unsafe.Pointer(p0)), p1, s2, s3)
+	ret := C.dyn_avformat_alloc_output_context2((**C.struct_AVFormatContext)(unsafe.Pointer(p0)), (*C.struct_AVOutputFormat)(unsafe.Pointer(p1)), s2, s3)
 	return *(*int32)(unsafe.Pointer(&ret))
 }
 func NewStream(p0 *Context, p1 *avcodec.Codec) *Stream {
@@ -335,6 +444,19 @@ func OpenIO(p0 **IOContext, p1 string, p2 int32) int32 {
 	ret := C.dyn_avio_open((**C.struct_AVIOContext)(unsafe.Pointer(p0)), s1, *(*C.int)(unsafe.Pointer(&p2)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func OpenIO2(p0 **IOContext, p1 string, p2 int32, p3 unsafe.Pointer, p4 **avutil.Dictionary) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p4)
+	ret := C.dyn_avio_open2((**C.struct_AVIOContext)(unsafe.Pointer(p0)), s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.struct_AVIOInterruptCB)(p3), (**C.struct_AVDictionary)(unsafe.Pointer(p4)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func OpenInput(p0 **Context, p1 string, p2 *InputFormat, p3 **avutil.Dictionary) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -348,6 +470,20 @@ func OpenInput(p0 **Context, p1 string, p2 *InputFormat, p3 **avutil.Dictionary)
 	ret := C.dyn_avformat_open_input((**C.struct_AVFormatContext)(unsafe.Pointer(p0)), s1, (*C.struct_AVInputFormat)(unsafe.Pointer(p2)), (**C.struct_AVDictionary)(unsafe.Pointer(p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func ProbeInputFormat(p0 *ProbeData, p1 int32, p2 *int32) *InputFormat {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	defer runtime.KeepAlive(p2)
+	return (*InputFormat)(unsafe.Pointer(C.dyn_av_probe_input_format3((*C.struct_AVProbeData)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)), (*C.int)(unsafe.Pointer(p2)))))
+}
+func QueryCodec(p0 *OutputFormat, p1 avcodec.ID, p2 int32) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p2)
+	ret := C.dyn_avformat_query_codec((*C.struct_AVOutputFormat)(unsafe.Pointer(p0)), (C.uint32_t)(p1), *(*C.int)(unsafe.Pointer(&p2)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func ReadFrame(p0 *Context, p1 *avcodec.Packet) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
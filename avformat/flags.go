@@ -10,5 +10,21 @@ const (
 )
 
 const (
-	NoFile = C.AVFMT_NOFILE
+	NoFile       = C.AVFMT_NOFILE
+	NeedNumber   = C.AVFMT_NEEDNUMBER
+	ShowIDs      = C.AVFMT_SHOW_IDS
+	GlobalHeader = C.AVFMT_GLOBALHEADER
+	NoTimestamps = C.AVFMT_NOTIMESTAMPS
+	GenericIndex = C.AVFMT_GENERIC_INDEX
+	TSDiscont    = C.AVFMT_TS_DISCONT
+	VariableFPS  = C.AVFMT_VARIABLE_FPS
+	NoDimensions = C.AVFMT_NODIMENSIONS
+	NoStreams    = C.AVFMT_NOSTREAMS
+	NoBinSearch  = C.AVFMT_NOBINSEARCH
+	NoGenSearch  = C.AVFMT_NOGENSEARCH
+	NoByteSeek   = C.AVFMT_NO_BYTE_SEEK
+	AllowFlush   = C.AVFMT_ALLOW_FLUSH
+	TSNonStrict  = C.AVFMT_TS_NONSTRICT
+	TSNegative   = C.AVFMT_TS_NEGATIVE
+	SeekToPTS    = C.AVFMT_SEEK_TO_PTS
 )
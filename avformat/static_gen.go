@@ -34,6 +34,14 @@ func FindBestStream(p0 *Context, p1 avutil.MediaType, p2 int32, p3 int32, p4 **a
 	ret := C.av_find_best_stream((*C.struct_AVFormatContext)(unsafe.Pointer(p0)), (int32)(p1), *(*C.int)(unsafe.Pointer(&p2)), *(*C.int)(unsafe.Pointer(&p3)), (**C.struct_AVCodec)(unsafe.Pointer(p4)), *(*C.int)(unsafe.Pointer(&p5)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func FindInputFormat(p0 string) *InputFormat {
+	var s0 *C.char
+	if p0 != "" {
+		s0 = C.CString(p0)
+		defer C.free(unsafe.Pointer(s0))
+	}
+	return (*InputFormat)(unsafe.Pointer(C.av_find_input_format(s0)))
+}
 func FindStreamInfo(p0 *Context, p1 **avutil.Dictionary) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -48,6 +56,24 @@ func FreeIOContext(p0 **IOContext) {
 	defer runtime.KeepAlive(p0)
 	C.avio_context_free((**C.struct_AVIOContext)(unsafe.Pointer(p0)))
 }
+func GuessFormat(p0 string, p1 string, p2 string) *OutputFormat {
+	var s0 *C.char
+	if p0 != "" {
+		s0 = C.CString(p0)
+		defer C.free(unsafe.Pointer(s0))
+	}
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	var s2 *C.char
+	if p2 != "" {
+		s2 = C.CString(p2)
+		defer C.free(unsafe.Pointer(s2))
+	}
+	return (*OutputFormat)(unsafe.Pointer(C.av_guess_format(s0, s1, s2)))
+}
 func GuessFrameRate(p0 *Context, p1 *Stream, p2 *avutil.Frame) avutil.Rational {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -55,6 +81,12 @@ func GuessFrameRate(p0 *Context, p1 *Stream, p2 *avutil.Frame) avutil.Rational {
 	ret := C.av_guess_frame_rate((*C.struct_AVFormatContext)(unsafe.Pointer(p0)), (*C.struct_AVStream)(unsafe.Pointer(p1)), (*C.struct_AVFrame)(unsafe.Pointer(p2)))
 	return *(*avutil.Rational)(unsafe.Pointer(&ret))
 }
+func IterateDemuxers(p0 *unsafe.Pointer) *InputFormat {
+	return (*InputFormat)(unsafe.Pointer(C.av_demuxer_iterate(p0)))
+}
+func IterateMuxers(p0 *unsafe.Pointer) *OutputFormat {
+	return (*OutputFormat)(unsafe.Pointer(C.av_muxer_iterate(p0)))
+}
 func NewContext() *Context {
 	return (*Context)(unsafe.Pointer(C.avformat_alloc_context()))
 }
@@ -64,8 +96,9 @@ func NewIOContext(p0 *byte, p1 int32, p2 int32, p3 unsafe.Pointer, p4 unsafe.Poi
 	defer runtime.KeepAlive(p2)
 	return (*IOContext)(unsafe.Pointer(C.avio_alloc_context((*C.uchar)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)), *(*C.int)(unsafe.Pointer(&p2)), p3, (*[0]byte)(p4), (*[0]byte)(p5), (*[0]byte)(p6))))
 }
-func NewOutputContext(p0 **Context, p1 *C.struct_AVOutputFormat, p2 string, p3 string) int32 {
+func NewOutputContext(p0 **Context, p1 *OutputFormat, p2 string, p3 string) int32 {
 	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
 	var s2 *C.char
 	if p2 != "" {
 		s2 = C.CString(p2)
@@ -76,7 +109,7 @@ func NewOutputContext(p0 **Context, p1 *C.struct_AVOutputFormat, p2 string, p3 s
 		s3 = C.CString(p3)
 		defer C.free(unsafe.Pointer(s3))
 	}
-	ret := C.avformat_alloc_output_context2((**C.struct_AVFormatContext)(unsafe.Pointer(p0)), p1, s2, s3)
+	ret := C.avformat_alloc_output_context2((**C.struct_AVFormatContext)(unsafe.Pointer(p0)), (*C.struct_AVOutputFormat)(unsafe.Pointer(p1)), s2, s3)
 	return *(*int32)(unsafe.Pointer(&ret))
 }
 func NewStream(p0 *Context, p1 *avcodec.Codec) *Stream {
@@ -95,6 +128,18 @@ func OpenIO(p0 **IOContext, p1 string, p2 int32) int32 {
 	ret := C.avio_open((**C.struct_AVIOContext)(unsafe.Pointer(p0)), s1, *(*C.int)(unsafe.Pointer(&p2)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func OpenIO2(p0 **IOContext, p1 string, p2 int32, p3 unsafe.Pointer, p4 **avutil.Dictionary) int32 {
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p4)
+	ret := C.avio_open2((**C.struct_AVIOContext)(unsafe.Pointer(p0)), s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.struct_AVIOInterruptCB)(p3), (**C.struct_AVDictionary)(unsafe.Pointer(p4)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func OpenInput(p0 **Context, p1 string, p2 *InputFormat, p3 **avutil.Dictionary) int32 {
 	defer runtime.KeepAlive(p0)
 	var s1 *C.char
@@ -107,6 +152,18 @@ func OpenInput(p0 **Context, p1 string, p2 *InputFormat, p3 **avutil.Dictionary)
 	ret := C.avformat_open_input((**C.struct_AVFormatContext)(unsafe.Pointer(p0)), s1, (*C.struct_AVInputFormat)(unsafe.Pointer(p2)), (**C.struct_AVDictionary)(unsafe.Pointer(p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func ProbeInputFormat(p0 *ProbeData, p1 int32, p2 *int32) *InputFormat {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	defer runtime.KeepAlive(p2)
+	return (*InputFormat)(unsafe.Pointer(C.av_probe_input_format3((*C.struct_AVProbeData)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)), (*C.int)(unsafe.Pointer(p2)))))
+}
+func QueryCodec(p0 *OutputFormat, p1 avcodec.ID, p2 int32) int32 {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p2)
+	ret := C.avformat_query_codec((*C.struct_AVOutputFormat)(unsafe.Pointer(p0)), (uint32)(p1), *(*C.int)(unsafe.Pointer(&p2)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func ReadFrame(p0 *Context, p1 *avcodec.Packet) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
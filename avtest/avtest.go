@@ -0,0 +1,64 @@
+// Package avtest provides utilities for testing code that uses go-av.
+package avtest
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ssttevee/go-av"
+)
+
+// CheckLeaks enables memory accounting and fails the test if packets, frames,
+// contexts or cgo handles that were created during the test are still alive
+// once it finishes. Unreachable objects are collected before checking, so
+// only objects that are still referenced or that can not be collected are
+// reported.
+//
+// Objects created by other tests that run in parallel are counted as well,
+// so it should not be used in parallel tests.
+func CheckLeaks(t testing.TB) {
+	t.Helper()
+
+	av.EnableMemoryAccounting()
+	before := av.ReadMemoryStats()
+
+	t.Cleanup(func() {
+		var leaked []string
+		for i := 0; i < 10; i++ {
+			// finalizers run on a separate goroutine after a collection, so
+			// give them some time to free the unreachable objects
+			runtime.GC()
+			time.Sleep(10 * time.Millisecond)
+
+			if leaked = leakedObjects(before, av.ReadMemoryStats()); len(leaked) == 0 {
+				return
+			}
+		}
+
+		t.Errorf("objects outlived the test: %s", strings.Join(leaked, ", "))
+	})
+}
+
+func leakedObjects(before, after av.MemoryStats) []string {
+	var kinds []av.ObjectKind
+	for kind := range after {
+		kinds = append(kinds, kind)
+	}
+
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i] < kinds[j]
+	})
+
+	var leaked []string
+	for _, kind := range kinds {
+		if n := after[kind].Count - before[kind].Count; n > 0 {
+			leaked = append(leaked, fmt.Sprintf("%d %s (%d bytes)", n, kind, after[kind].Bytes-before[kind].Bytes))
+		}
+	}
+
+	return leaked
+}
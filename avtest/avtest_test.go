@@ -0,0 +1,63 @@
+package avtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ssttevee/go-av"
+)
+
+func TestLeakedObjects(t *testing.T) {
+	tests := []struct {
+		name   string
+		before av.MemoryStats
+		after  av.MemoryStats
+		want   []string
+	}{
+		{
+			name:   "empty",
+			before: av.MemoryStats{},
+			after:  av.MemoryStats{},
+		},
+		{
+			name:   "unchanged",
+			before: av.MemoryStats{av.PacketObject: {Count: 2, Bytes: 100}},
+			after:  av.MemoryStats{av.PacketObject: {Count: 2, Bytes: 100}},
+		},
+		{
+			name:   "freed",
+			before: av.MemoryStats{av.FrameObject: {Count: 3, Bytes: 300}},
+			after:  av.MemoryStats{av.FrameObject: {Count: 1, Bytes: 100}},
+		},
+		{
+			name:   "new kind",
+			before: av.MemoryStats{},
+			after:  av.MemoryStats{av.HandleObject: {Count: 1}},
+			want:   []string{"1 cgo handle (0 bytes)"},
+		},
+		{
+			name: "sorted by kind",
+			before: av.MemoryStats{
+				av.PacketObject: {Count: 1, Bytes: 64},
+			},
+			after: av.MemoryStats{
+				av.HandleObject:        {Count: 2},
+				av.FormatContextObject: {Count: 1, Bytes: 1024},
+				av.PacketObject:        {Count: 3, Bytes: 192},
+			},
+			want: []string{
+				"2 packet (128 bytes)",
+				"1 format context (1024 bytes)",
+				"2 cgo handle (0 bytes)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := leakedObjects(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("leakedObjects() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,21 +4,31 @@ package avutil
 
 // #include <libavutil/avutil.h>
 // #include <libavutil/buffer.h>
+// #include <libavutil/channel_layout.h>
 // #include <libavutil/dict.h>
+// #include <libavutil/display.h>
 // #include <libavutil/frame.h>
 // #include <libavutil/pixdesc.h>
 // #include <libavutil/hwcontext.h>
 // #include <libavutil/log.h>
+// #include <libavutil/mastering_display_metadata.h>
 // #include <libavutil/opt.h>
+// #include <libavutil/parseutils.h>
+// #include <libavutil/samplefmt.h>
 import "C"
 
 // +gen convtype struct_AVClass Class
 // +gen convtype struct_AVDictionary Dictionary
+// +gen convtype struct_AVDictionaryEntry DictionaryEntry
 // +gen convtype struct_AVFrame Frame
 // +gen convtype struct_AVBufferRef BufferRef
 // +gen convtype struct_AVFrameSideData FrameSideData
+// +gen convtype struct_AVMasteringDisplayMetadata MasteringDisplayMetadata
+// +gen convtype struct_AVContentLightMetadata ContentLightMetadata
 // +gen convtype struct_AVRational Rational
 // +gen convtype struct_AVOption Option
+// +gen convtype struct_AVPixFmtDescriptor PixFmtDescriptor
+// +gen convtype struct_AVComponentDescriptor ComponentDescriptor
 // +gen convtype struct_AVHWDeviceContext HWDeviceContext
 // +gen convtype struct_AVHWFramesContext HWFramesContext
 
@@ -26,18 +36,33 @@ import "C"
 // +gen fieldtype struct_AVHWFramesContext format PixelFormat
 // +gen fieldtype struct_AVHWFramesContext sw_format PixelFormat
 // +gen fieldtype struct_AVOption _type OptionType
+// +gen fieldtype struct_AVFrameSideData _type FrameSideDataType
 
 // +gen wrapfunc av_frame_alloc NewFrame
 // +gen wrapfunc av_frame_free FreeFrame
 // +gen wrapfunc av_frame_ref RefFrame
 // +gen wrapfunc av_frame_unref UnrefFrame
 // +gen wrapfunc av_frame_copy_props CopyFrameProps
+// +gen wrapfunc av_frame_get_buffer GetFrameBuffer
+// +gen wrapfunc av_frame_make_writable MakeFrameWritable
+// +gen wrapfunc av_frame_new_side_data NewFrameSideData
+// +gen wrapfunc av_frame_remove_side_data RemoveFrameSideData
+// +gen wrapfunc av_frame_side_data_name getFrameSideDataName
+
+// +gen wrapfunc av_display_rotation_get GetDisplayRotation
+// +gen wrapfunc av_display_rotation_set SetDisplayRotation
 
 // +gen wrapfunc av_buffer_ref RefBuffer
 // +gen wrapfunc av_buffer_unref UnrefBuffer
+// +gen wrapfunc av_buffer_create CreateBuffer
+
+// +gen paramtype av_buffer_create 2 unsafe.Pointer
 
 // +gen wrapfunc av_dict_set SetDict
 // +gen wrapfunc av_dict_free FreeDict
+// +gen wrapfunc av_dict_get GetDict
+// +gen wrapfunc av_dict_count CountDict
+// +gen wrapfunc av_dict_parse_string ParseDictString
 
 // +gen wrapfunc av_opt_set SetOpt
 // +gen wrapfunc av_opt_set_int SetOptInt
@@ -45,7 +70,22 @@ import "C"
 // +gen wrapfunc av_opt_set_q SetOptRational
 // +gen wrapfunc av_opt_set_pixel_fmt SetOptPixelFormat
 // +gen wrapfunc av_opt_set_bin SetOptBin
+// +gen wrapfunc av_opt_set_image_size SetOptImageSize
+// +gen wrapfunc av_opt_set_sample_fmt SetOptSampleFormat
+// +gen wrapfunc av_opt_set_dict_val SetOptDict
+// +gen wrapfunc av_opt_get GetOpt
+// +gen wrapfunc av_opt_get_int GetOptInt
+// +gen wrapfunc av_opt_get_double GetOptDouble
+// +gen wrapfunc av_opt_get_q GetOptRational
+// +gen wrapfunc av_opt_get_image_size GetOptImageSize
+// +gen wrapfunc av_opt_get_dict_val GetOptDict
 // +gen wrapfunc av_opt_find2 FindOpt
+// +gen wrapfunc av_opt_next NextOpt
+// +gen wrapfunc av_opt_child_next NextOptChild
+
+// +gen wrapfunc av_parse_video_size ParseVideoSize
+// +gen wrapfunc av_parse_video_rate ParseVideoRate
+// +gen wrapfunc av_parse_color ParseColor
 
 // +gen wrapfunc av_hwdevice_ctx_create NewHWDeviceContext
 
@@ -54,13 +94,26 @@ import "C"
 // +gen wrapfunc av_hwframe_get_buffer GetHWFrameBuffer
 // +gen wrapfunc av_hwframe_transfer_data TransferHWFrameData
 
+// +gen wrapfunc av_get_default_channel_layout GetDefaultChannelLayout
+// +gen wrapfunc av_get_channel_layout_nb_channels GetChannelLayoutNbChannels
+
 // +gen wrapfunc av_rescale_rnd RescaleRound
 // +gen wrapfunc av_mul_q MultiplyRational
+// +gen wrapfunc av_d2q DoubleToRational
 // +gen wrapfunc av_strdup DupeString
 // +gen wrapfunc av_free Free
 // +gen wrapfunc av_malloc Malloc
+// +gen wrapfunc av_mallocz MallocZeroed
+// +gen wrapfunc av_dynarray_add_nofree AddDynarray
 // +gen wrapfunc av_get_pix_fmt_name getPixelFormatName
 // +gen wrapfunc av_get_sample_fmt_name getSampleFormatName
+// +gen wrapfunc av_get_pix_fmt getPixelFormat
+// +gen wrapfunc av_get_sample_fmt getSampleFormat
+// +gen wrapfunc av_get_bytes_per_sample getBytesPerSample
+// +gen wrapfunc av_sample_fmt_is_planar isPlanarSampleFormat
+// +gen wrapfunc av_pix_fmt_count_planes countPixelFormatPlanes
+// +gen wrapfunc av_pix_fmt_get_chroma_sub_sample getChromaSubsample
+// +gen wrapfunc av_pix_fmt_desc_get getPixelFormatDescriptor
 // +gen wrapfunc av_get_media_type_string getMediaTypeString
 // +gen wrapfunc av_color_range_name getColorRangeName
 // +gen wrapfunc av_color_primaries_name getColorPrimariesName
@@ -72,7 +125,18 @@ import "C"
 // +gen paramtype av_rescale_rnd 3 Rounding
 // +gen paramtype av_hwdevice_ctx_create 1 HWDeviceType
 // +gen paramtype av_opt_set_pixel_fmt 2 PixelFormat
+// +gen paramtype av_opt_set_sample_fmt 2 SampleFormat
 // +gen paramtype av_get_pix_fmt_name 0 PixelFormat
 // +gen paramtype av_get_sample_fmt_name 0 SampleFormat
+// +gen paramtype av_get_bytes_per_sample 0 SampleFormat
+// +gen paramtype av_sample_fmt_is_planar 0 SampleFormat
+// +gen paramtype av_pix_fmt_count_planes 0 PixelFormat
+// +gen paramtype av_pix_fmt_get_chroma_sub_sample 0 PixelFormat
+// +gen paramtype av_pix_fmt_desc_get 0 PixelFormat
 // +gen paramtype av_get_media_type_string 0 MediaType
 // +gen paramtype av_hwdevice_get_type_name 0 HWDeviceType
+// +gen paramtype av_frame_new_side_data 1 FrameSideDataType
+// +gen paramtype av_frame_remove_side_data 1 FrameSideDataType
+// +gen paramtype av_frame_side_data_name 0 FrameSideDataType
+// +gen paramtype av_display_rotation_get 0 *int32
+// +gen paramtype av_display_rotation_set 0 *int32
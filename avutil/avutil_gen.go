@@ -10,12 +10,17 @@ import (
 /*
 #include <libavutil/avutil.h>
 #include <libavutil/buffer.h>
+#include <libavutil/channel_layout.h>
 #include <libavutil/dict.h>
+#include <libavutil/display.h>
 #include <libavutil/frame.h>
 #include <libavutil/pixdesc.h>
 #include <libavutil/hwcontext.h>
 #include <libavutil/log.h>
+#include <libavutil/mastering_display_metadata.h>
 #include <libavutil/opt.h>
+#include <libavutil/parseutils.h>
+#include <libavutil/samplefmt.h>
 */
 import "C"
 
@@ -38,6 +43,24 @@ type Class struct {
 	GetCategory            *[0]byte
 	QueryRanges            *[0]byte
 }
+type ComponentDescriptor struct {
+	Plane       int32
+	Step        int32
+	Offset      int32
+	Shift       int32
+	Depth       int32
+	StepMinus1  int32
+	DepthMinus1 int32
+	OffsetPlus1 int32
+}
+type ContentLightMetadata struct {
+	MaxCLL  uint32
+	MaxFALL uint32
+}
+type DictionaryEntry struct {
+	Key   *common.CChar
+	Value *common.CChar
+}
 type Frame struct {
 	Data                 [8]*uint8
 	Linesize             [8]int32
@@ -95,7 +118,7 @@ type Frame struct {
 	PrivateRef           *BufferRef
 }
 type FrameSideData struct {
-	Type     uint32
+	Type     FrameSideDataType
 	Data     *uint8
 	Size     int32
 	Metadata *Dictionary
@@ -125,6 +148,14 @@ type HWFramesContext struct {
 	Height          int32
 	_               [4]byte
 }
+type MasteringDisplayMetadata struct {
+	DisplayPrimaries [3][2]Rational
+	WhitePoint       [2]Rational
+	MinLuminance     Rational
+	MaxLuminance     Rational
+	HasPrimaries     int32
+	HasLuminance     int32
+}
 type Option struct {
 	Name       *common.CChar
 	Help       *common.CChar
@@ -136,6 +167,16 @@ type Option struct {
 	Flags      int32
 	Unit       *common.CChar
 }
+type PixFmtDescriptor struct {
+	Name         *common.CChar
+	NbComponents uint8
+	Log2ChromaW  uint8
+	Log2ChromaH  uint8
+	_            [5]byte
+	Flags        uint64
+	Comp         [4]ComponentDescriptor
+	Alias        *common.CChar
+}
 type Rational struct {
 	Num int32
 	Den int32
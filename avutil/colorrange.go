@@ -8,6 +8,8 @@ type ColorRange C.enum_AVColorRange
 
 const (
 	ColorRangeUnspecified = ColorRange(C.AVCOL_RANGE_UNSPECIFIED)
+	ColorRangeMPEG        = ColorRange(C.AVCOL_RANGE_MPEG)
+	ColorRangeJPEG        = ColorRange(C.AVCOL_RANGE_JPEG)
 )
 
 func (cr ColorRange) String() string {
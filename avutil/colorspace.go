@@ -7,7 +7,15 @@ import "C"
 type ColorSpace C.enum_AVColorSpace
 
 const (
+	ColorSpaceRGB         = ColorSpace(C.AVCOL_SPC_RGB)
+	ColorSpaceBT709       = ColorSpace(C.AVCOL_SPC_BT709)
 	ColorSpaceUnspecified = ColorSpace(C.AVCOL_SPC_UNSPECIFIED)
+	ColorSpaceFCC         = ColorSpace(C.AVCOL_SPC_FCC)
+	ColorSpaceBT470BG     = ColorSpace(C.AVCOL_SPC_BT470BG)
+	ColorSpaceSMPTE170M   = ColorSpace(C.AVCOL_SPC_SMPTE170M)
+	ColorSpaceSMPTE240M   = ColorSpace(C.AVCOL_SPC_SMPTE240M)
+	ColorSpaceBT2020NCL   = ColorSpace(C.AVCOL_SPC_BT2020_NCL)
+	ColorSpaceBT2020CL    = ColorSpace(C.AVCOL_SPC_BT2020_CL)
 )
 
 func (cs ColorSpace) String() string {
@@ -5,4 +5,6 @@ import "C"
 
 const (
 	TimeBase = C.AV_TIME_BASE
+
+	NoPtsValue int64 = C.AV_NOPTS_VALUE
 )
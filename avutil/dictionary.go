@@ -1,3 +1,27 @@
 package avutil
 
+// #include <libavutil/dict.h>
+import "C"
+
 type Dictionary struct{}
+
+const (
+	DictMatchCase     = C.AV_DICT_MATCH_CASE
+	DictIgnoreSuffix  = C.AV_DICT_IGNORE_SUFFIX
+	DictDontOverwrite = C.AV_DICT_DONT_OVERWRITE
+	DictAppend        = C.AV_DICT_APPEND
+)
+
+// DictEntries returns all entries of the dictionary in order.
+func DictEntries(m *Dictionary) []*DictionaryEntry {
+	var entries []*DictionaryEntry
+
+	// an empty key matches every entry when suffixes are ignored, but an empty
+	// go string is converted to a null pointer, so use a string that is empty
+	// in c instead
+	for entry := GetDict(m, "\x00", nil, DictIgnoreSuffix); entry != nil; entry = GetDict(m, "\x00", entry, DictIgnoreSuffix) {
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
@@ -21,18 +21,46 @@ import (
 
 struct AVBufferRef;
 struct AVDictionary;
+struct AVDictionaryEntry;
 struct AVFrame;
+struct AVFrameSideData;
 struct AVOption;
+struct AVPixFmtDescriptor;
+struct AVRational;
 struct AVRational{};
 
 static void *handle = 0;
 
+static int (*_av_dynarray_add_nofree)(void*, int*, void*);
+
+int dyn_av_dynarray_add_nofree(void* p0, int* p1, void* p2) {
+    return _av_dynarray_add_nofree(p0, p1, p2);
+};
+
 static int (*_av_frame_copy_props)(struct AVFrame*, struct AVFrame*);
 
 int dyn_av_frame_copy_props(struct AVFrame* p0, struct AVFrame* p1) {
     return _av_frame_copy_props(p0, p1);
 };
 
+static int (*_av_dict_count)(struct AVDictionary*);
+
+int dyn_av_dict_count(struct AVDictionary* p0) {
+    return _av_dict_count(p0);
+};
+
+static struct AVBufferRef* (*_av_buffer_create)(uint8_t*, int, void (*p2)(), void*, int);
+
+struct AVBufferRef* dyn_av_buffer_create(uint8_t* p0, int p1, void (*p2)(), void* p3, int p4) {
+    return _av_buffer_create(p0, p1, p2, p3, p4);
+};
+
+static struct AVRational (*_av_d2q)(double, int);
+
+struct AVRational dyn_av_d2q(double p0, int p1) {
+    return _av_d2q(p0, p1);
+};
+
 static char* (*_av_strdup)(char*);
 
 char* dyn_av_strdup(char* p0) {
@@ -63,24 +91,102 @@ void dyn_av_frame_free(struct AVFrame** p0) {
     _av_frame_free(p0);
 };
 
+static int (*_av_get_channel_layout_nb_channels)(uint64_t);
+
+int dyn_av_get_channel_layout_nb_channels(uint64_t p0) {
+    return _av_get_channel_layout_nb_channels(p0);
+};
+
+static int64_t (*_av_get_default_channel_layout)(int);
+
+int64_t dyn_av_get_default_channel_layout(int p0) {
+    return _av_get_default_channel_layout(p0);
+};
+
+static struct AVDictionaryEntry* (*_av_dict_get)(struct AVDictionary*, char*, struct AVDictionaryEntry*, int);
+
+struct AVDictionaryEntry* dyn_av_dict_get(struct AVDictionary* p0, char* p1, struct AVDictionaryEntry* p2, int p3) {
+    return _av_dict_get(p0, p1, p2, p3);
+};
+
+static double (*_av_display_rotation_get)(int32_t*);
+
+double dyn_av_display_rotation_get(int32_t* p0) {
+    return _av_display_rotation_get(p0);
+};
+
+static int (*_av_frame_get_buffer)(struct AVFrame*, int);
+
+int dyn_av_frame_get_buffer(struct AVFrame* p0, int p1) {
+    return _av_frame_get_buffer(p0, p1);
+};
+
 static int (*_av_hwframe_get_buffer)(struct AVBufferRef*, struct AVFrame*, int);
 
 int dyn_av_hwframe_get_buffer(struct AVBufferRef* p0, struct AVFrame* p1, int p2) {
     return _av_hwframe_get_buffer(p0, p1, p2);
 };
 
+static int (*_av_opt_get)(void*, char*, int, uint8_t**);
+
+int dyn_av_opt_get(void* p0, char* p1, int p2, uint8_t** p3) {
+    return _av_opt_get(p0, p1, p2, p3);
+};
+
+static int (*_av_opt_get_dict_val)(void*, char*, int, struct AVDictionary**);
+
+int dyn_av_opt_get_dict_val(void* p0, char* p1, int p2, struct AVDictionary** p3) {
+    return _av_opt_get_dict_val(p0, p1, p2, p3);
+};
+
+static int (*_av_opt_get_double)(void*, char*, int, double*);
+
+int dyn_av_opt_get_double(void* p0, char* p1, int p2, double* p3) {
+    return _av_opt_get_double(p0, p1, p2, p3);
+};
+
+static int (*_av_opt_get_image_size)(void*, char*, int, int*, int*);
+
+int dyn_av_opt_get_image_size(void* p0, char* p1, int p2, int* p3, int* p4) {
+    return _av_opt_get_image_size(p0, p1, p2, p3, p4);
+};
+
+static int (*_av_opt_get_int)(void*, char*, int, int64_t*);
+
+int dyn_av_opt_get_int(void* p0, char* p1, int p2, int64_t* p3) {
+    return _av_opt_get_int(p0, p1, p2, p3);
+};
+
+static int (*_av_opt_get_q)(void*, char*, int, struct AVRational*);
+
+int dyn_av_opt_get_q(void* p0, char* p1, int p2, struct AVRational* p3) {
+    return _av_opt_get_q(p0, p1, p2, p3);
+};
+
 static int (*_av_hwframe_ctx_init)(struct AVBufferRef*);
 
 int dyn_av_hwframe_ctx_init(struct AVBufferRef* p0) {
     return _av_hwframe_ctx_init(p0);
 };
 
+static int (*_av_frame_make_writable)(struct AVFrame*);
+
+int dyn_av_frame_make_writable(struct AVFrame* p0) {
+    return _av_frame_make_writable(p0);
+};
+
 static void* (*_av_malloc)(size_t);
 
 void* dyn_av_malloc(size_t p0) {
     return _av_malloc(p0);
 };
 
+static void* (*_av_mallocz)(size_t);
+
+void* dyn_av_mallocz(size_t p0) {
+    return _av_mallocz(p0);
+};
+
 static struct AVRational (*_av_mul_q)(struct AVRational, struct AVRational);
 
 struct AVRational dyn_av_mul_q(struct AVRational p0, struct AVRational p1) {
@@ -93,6 +199,12 @@ struct AVFrame* dyn_av_frame_alloc() {
     return _av_frame_alloc();
 };
 
+static struct AVFrameSideData* (*_av_frame_new_side_data)(struct AVFrame*, uint32_t, int);
+
+struct AVFrameSideData* dyn_av_frame_new_side_data(struct AVFrame* p0, uint32_t p1, int p2) {
+    return _av_frame_new_side_data(p0, p1, p2);
+};
+
 static int (*_av_hwdevice_ctx_create)(struct AVBufferRef**, uint32_t, char*, struct AVDictionary*, int);
 
 int dyn_av_hwdevice_ctx_create(struct AVBufferRef** p0, uint32_t p1, char* p2, struct AVDictionary* p3, int p4) {
@@ -105,6 +217,42 @@ struct AVBufferRef* dyn_av_hwframe_ctx_alloc(struct AVBufferRef* p0) {
     return _av_hwframe_ctx_alloc(p0);
 };
 
+static struct AVOption* (*_av_opt_next)(void*, struct AVOption*);
+
+struct AVOption* dyn_av_opt_next(void* p0, struct AVOption* p1) {
+    return _av_opt_next(p0, p1);
+};
+
+static void* (*_av_opt_child_next)(void*, void*);
+
+void* dyn_av_opt_child_next(void* p0, void* p1) {
+    return _av_opt_child_next(p0, p1);
+};
+
+static int (*_av_parse_color)(uint8_t*, char*, int, void*);
+
+int dyn_av_parse_color(uint8_t* p0, char* p1, int p2, void* p3) {
+    return _av_parse_color(p0, p1, p2, p3);
+};
+
+static int (*_av_dict_parse_string)(struct AVDictionary**, char*, char*, char*, int);
+
+int dyn_av_dict_parse_string(struct AVDictionary** p0, char* p1, char* p2, char* p3, int p4) {
+    return _av_dict_parse_string(p0, p1, p2, p3, p4);
+};
+
+static int (*_av_parse_video_rate)(struct AVRational*, char*);
+
+int dyn_av_parse_video_rate(struct AVRational* p0, char* p1) {
+    return _av_parse_video_rate(p0, p1);
+};
+
+static int (*_av_parse_video_size)(int*, int*, char*);
+
+int dyn_av_parse_video_size(int* p0, int* p1, char* p2) {
+    return _av_parse_video_size(p0, p1, p2);
+};
+
 static struct AVBufferRef* (*_av_buffer_ref)(struct AVBufferRef*);
 
 struct AVBufferRef* dyn_av_buffer_ref(struct AVBufferRef* p0) {
@@ -117,6 +265,12 @@ int dyn_av_frame_ref(struct AVFrame* p0, struct AVFrame* p1) {
     return _av_frame_ref(p0, p1);
 };
 
+static void (*_av_frame_remove_side_data)(struct AVFrame*, uint32_t);
+
+void dyn_av_frame_remove_side_data(struct AVFrame* p0, uint32_t p1) {
+    _av_frame_remove_side_data(p0, p1);
+};
+
 static int64_t (*_av_rescale_rnd)(int64_t, int64_t, int64_t, uint32_t);
 
 int64_t dyn_av_rescale_rnd(int64_t p0, int64_t p1, int64_t p2, uint32_t p3) {
@@ -129,6 +283,12 @@ int dyn_av_dict_set(struct AVDictionary** p0, char* p1, char* p2, int p3) {
     return _av_dict_set(p0, p1, p2, p3);
 };
 
+static void (*_av_display_rotation_set)(int32_t*, double);
+
+void dyn_av_display_rotation_set(int32_t* p0, double p1) {
+    _av_display_rotation_set(p0, p1);
+};
+
 static int (*_av_opt_set)(void*, char*, char*, int);
 
 int dyn_av_opt_set(void* p0, char* p1, char* p2, int p3) {
@@ -141,12 +301,24 @@ int dyn_av_opt_set_bin(void* p0, char* p1, uint8_t* p2, int p3, int p4) {
     return _av_opt_set_bin(p0, p1, p2, p3, p4);
 };
 
+static int (*_av_opt_set_dict_val)(void*, char*, struct AVDictionary*, int);
+
+int dyn_av_opt_set_dict_val(void* p0, char* p1, struct AVDictionary* p2, int p3) {
+    return _av_opt_set_dict_val(p0, p1, p2, p3);
+};
+
 static int (*_av_opt_set_double)(void*, char*, double, int);
 
 int dyn_av_opt_set_double(void* p0, char* p1, double p2, int p3) {
     return _av_opt_set_double(p0, p1, p2, p3);
 };
 
+static int (*_av_opt_set_image_size)(void*, char*, int, int, int);
+
+int dyn_av_opt_set_image_size(void* p0, char* p1, int p2, int p3, int p4) {
+    return _av_opt_set_image_size(p0, p1, p2, p3, p4);
+};
+
 static int (*_av_opt_set_int)(void*, char*, int64_t, int);
 
 int dyn_av_opt_set_int(void* p0, char* p1, int64_t p2, int p3) {
@@ -165,6 +337,12 @@ int dyn_av_opt_set_q(void* p0, char* p1, struct AVRational p2, int p3) {
     return _av_opt_set_q(p0, p1, p2, p3);
 };
 
+static int (*_av_opt_set_sample_fmt)(void*, char*, int32_t, int);
+
+int dyn_av_opt_set_sample_fmt(void* p0, char* p1, int32_t p2, int p3) {
+    return _av_opt_set_sample_fmt(p0, p1, p2, p3);
+};
+
 static int (*_av_hwframe_transfer_data)(struct AVFrame*, struct AVFrame*, int);
 
 int dyn_av_hwframe_transfer_data(struct AVFrame* p0, struct AVFrame* p1, int p2) {
@@ -183,12 +361,30 @@ void dyn_av_frame_unref(struct AVFrame* p0) {
     _av_frame_unref(p0);
 };
 
+static int (*_av_pix_fmt_count_planes)(int32_t);
+
+int dyn_av_pix_fmt_count_planes(int32_t p0) {
+    return _av_pix_fmt_count_planes(p0);
+};
+
+static int (*_av_get_bytes_per_sample)(int32_t);
+
+int dyn_av_get_bytes_per_sample(int32_t p0) {
+    return _av_get_bytes_per_sample(p0);
+};
+
 static char* (*_av_chroma_location_name)(uint32_t);
 
 char* dyn_av_chroma_location_name(uint32_t p0) {
     return _av_chroma_location_name(p0);
 };
 
+static int (*_av_pix_fmt_get_chroma_sub_sample)(int32_t, int*, int*);
+
+int dyn_av_pix_fmt_get_chroma_sub_sample(int32_t p0, int* p1, int* p2) {
+    return _av_pix_fmt_get_chroma_sub_sample(p0, p1, p2);
+};
+
 static char* (*_av_color_primaries_name)(uint32_t);
 
 char* dyn_av_color_primaries_name(uint32_t p0) {
@@ -213,6 +409,12 @@ char* dyn_av_color_transfer_name(uint32_t p0) {
     return _av_color_transfer_name(p0);
 };
 
+static char* (*_av_frame_side_data_name)(uint32_t);
+
+char* dyn_av_frame_side_data_name(uint32_t p0) {
+    return _av_frame_side_data_name(p0);
+};
+
 static char* (*_av_hwdevice_get_type_name)(uint32_t);
 
 char* dyn_av_hwdevice_get_type_name(uint32_t p0) {
@@ -225,28 +427,68 @@ char* dyn_av_get_media_type_string(int32_t p0) {
     return _av_get_media_type_string(p0);
 };
 
+static int32_t (*_av_get_pix_fmt)(char*);
+
+int32_t dyn_av_get_pix_fmt(char* p0) {
+    return _av_get_pix_fmt(p0);
+};
+
+static struct AVPixFmtDescriptor* (*_av_pix_fmt_desc_get)(int32_t);
+
+struct AVPixFmtDescriptor* dyn_av_pix_fmt_desc_get(int32_t p0) {
+    return _av_pix_fmt_desc_get(p0);
+};
+
 static char* (*_av_get_pix_fmt_name)(int32_t);
 
 char* dyn_av_get_pix_fmt_name(int32_t p0) {
     return _av_get_pix_fmt_name(p0);
 };
 
+static int32_t (*_av_get_sample_fmt)(char*);
+
+int32_t dyn_av_get_sample_fmt(char* p0) {
+    return _av_get_sample_fmt(p0);
+};
+
 static char* (*_av_get_sample_fmt_name)(int32_t);
 
 char* dyn_av_get_sample_fmt_name(int32_t p0) {
     return _av_get_sample_fmt_name(p0);
 };
 
+static int (*_av_sample_fmt_is_planar)(int32_t);
+
+int dyn_av_sample_fmt_is_planar(int32_t p0) {
+    return _av_sample_fmt_is_planar(p0);
+};
+
 char *goav_load_avutil() {
     char *ret;
     handle = dlopen("libavutil.so", RTLD_NOW | RTLD_GLOBAL);
     if (ret = dlerror()) {
         return ret;
     }
+    _av_dynarray_add_nofree = dlsym(handle, "av_dynarray_add_nofree");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_frame_copy_props = dlsym(handle, "av_frame_copy_props");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_dict_count = dlsym(handle, "av_dict_count");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_buffer_create = dlsym(handle, "av_buffer_create");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_d2q = dlsym(handle, "av_d2q");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_strdup = dlsym(handle, "av_strdup");
     if (ret = dlerror()) {
         return ret;
@@ -267,18 +509,70 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_get_channel_layout_nb_channels = dlsym(handle, "av_get_channel_layout_nb_channels");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_get_default_channel_layout = dlsym(handle, "av_get_default_channel_layout");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_dict_get = dlsym(handle, "av_dict_get");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_display_rotation_get = dlsym(handle, "av_display_rotation_get");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_frame_get_buffer = dlsym(handle, "av_frame_get_buffer");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_hwframe_get_buffer = dlsym(handle, "av_hwframe_get_buffer");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_opt_get = dlsym(handle, "av_opt_get");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_opt_get_dict_val = dlsym(handle, "av_opt_get_dict_val");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_opt_get_double = dlsym(handle, "av_opt_get_double");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_opt_get_image_size = dlsym(handle, "av_opt_get_image_size");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_opt_get_int = dlsym(handle, "av_opt_get_int");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_opt_get_q = dlsym(handle, "av_opt_get_q");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_hwframe_ctx_init = dlsym(handle, "av_hwframe_ctx_init");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_frame_make_writable = dlsym(handle, "av_frame_make_writable");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_malloc = dlsym(handle, "av_malloc");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_mallocz = dlsym(handle, "av_mallocz");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_mul_q = dlsym(handle, "av_mul_q");
     if (ret = dlerror()) {
         return ret;
@@ -287,6 +581,10 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_frame_new_side_data = dlsym(handle, "av_frame_new_side_data");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_hwdevice_ctx_create = dlsym(handle, "av_hwdevice_ctx_create");
     if (ret = dlerror()) {
         return ret;
@@ -295,6 +593,30 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_opt_next = dlsym(handle, "av_opt_next");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_opt_child_next = dlsym(handle, "av_opt_child_next");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_parse_color = dlsym(handle, "av_parse_color");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_dict_parse_string = dlsym(handle, "av_dict_parse_string");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_parse_video_rate = dlsym(handle, "av_parse_video_rate");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_parse_video_size = dlsym(handle, "av_parse_video_size");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_buffer_ref = dlsym(handle, "av_buffer_ref");
     if (ret = dlerror()) {
         return ret;
@@ -303,6 +625,10 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_frame_remove_side_data = dlsym(handle, "av_frame_remove_side_data");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_rescale_rnd = dlsym(handle, "av_rescale_rnd");
     if (ret = dlerror()) {
         return ret;
@@ -311,6 +637,10 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_display_rotation_set = dlsym(handle, "av_display_rotation_set");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_opt_set = dlsym(handle, "av_opt_set");
     if (ret = dlerror()) {
         return ret;
@@ -319,10 +649,18 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_opt_set_dict_val = dlsym(handle, "av_opt_set_dict_val");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_opt_set_double = dlsym(handle, "av_opt_set_double");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_opt_set_image_size = dlsym(handle, "av_opt_set_image_size");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_opt_set_int = dlsym(handle, "av_opt_set_int");
     if (ret = dlerror()) {
         return ret;
@@ -335,6 +673,10 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_opt_set_sample_fmt = dlsym(handle, "av_opt_set_sample_fmt");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_hwframe_transfer_data = dlsym(handle, "av_hwframe_transfer_data");
     if (ret = dlerror()) {
         return ret;
@@ -347,10 +689,22 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_pix_fmt_count_planes = dlsym(handle, "av_pix_fmt_count_planes");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_get_bytes_per_sample = dlsym(handle, "av_get_bytes_per_sample");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_chroma_location_name = dlsym(handle, "av_chroma_location_name");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_pix_fmt_get_chroma_sub_sample = dlsym(handle, "av_pix_fmt_get_chroma_sub_sample");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_color_primaries_name = dlsym(handle, "av_color_primaries_name");
     if (ret = dlerror()) {
         return ret;
@@ -367,6 +721,10 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_frame_side_data_name = dlsym(handle, "av_frame_side_data_name");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_hwdevice_get_type_name = dlsym(handle, "av_hwdevice_get_type_name");
     if (ret = dlerror()) {
         return ret;
@@ -375,14 +733,30 @@ char *goav_load_avutil() {
     if (ret = dlerror()) {
         return ret;
     }
+    _av_get_pix_fmt = dlsym(handle, "av_get_pix_fmt");
+    if (ret = dlerror()) {
+        return ret;
+    }
+    _av_pix_fmt_desc_get = dlsym(handle, "av_pix_fmt_desc_get");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_get_pix_fmt_name = dlsym(handle, "av_get_pix_fmt_name");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_get_sample_fmt = dlsym(handle, "av_get_sample_fmt");
+    if (ret = dlerror()) {
+        return ret;
+    }
     _av_get_sample_fmt_name = dlsym(handle, "av_get_sample_fmt_name");
     if (ret = dlerror()) {
         return ret;
     }
+    _av_sample_fmt_is_planar = dlsym(handle, "av_sample_fmt_is_planar");
+    if (ret = dlerror()) {
+        return ret;
+    }
     return 0;
 }
 */
@@ -408,6 +782,12 @@ func dynamicInit() {
 		panic(initError)
 	}
 }
+func AddDynarray(p0 unsafe.Pointer, p1 *int32, p2 unsafe.Pointer) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p1)
+	ret := C.dyn_av_dynarray_add_nofree(p0, (*C.int)(unsafe.Pointer(p1)), p2)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func CopyFrameProps(p0 *Frame, p1 *Frame) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -415,6 +795,26 @@ func CopyFrameProps(p0 *Frame, p1 *Frame) int32 {
 	ret := C.dyn_av_frame_copy_props((*C.struct_AVFrame)(unsafe.Pointer(p0)), (*C.struct_AVFrame)(unsafe.Pointer(p1)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func CountDict(p0 *Dictionary) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_dict_count((*C.struct_AVDictionary)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func CreateBuffer(p0 *uint8, p1 int32, p2 unsafe.Pointer, p3 unsafe.Pointer, p4 int32) *BufferRef {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	defer runtime.KeepAlive(p4)
+	return (*BufferRef)(unsafe.Pointer(C.dyn_av_buffer_create((*C.uint8_t)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)), (*[0]byte)(p2), p3, *(*C.int)(unsafe.Pointer(&p4)))))
+}
+func DoubleToRational(p0 float64, p1 int32) Rational {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	ret := C.dyn_av_d2q(*(*C.double)(unsafe.Pointer(&p0)), *(*C.int)(unsafe.Pointer(&p1)))
+	return *(*Rational)(unsafe.Pointer(&ret))
+}
 func DupeString(p0 string) *common.CChar {
 	dynamicInit()
 	var s0 *C.char
@@ -454,6 +854,42 @@ func FreeFrame(p0 **Frame) {
 	defer runtime.KeepAlive(p0)
 	C.dyn_av_frame_free((**C.struct_AVFrame)(unsafe.Pointer(p0)))
 }
+func GetChannelLayoutNbChannels(p0 uint64) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_get_channel_layout_nb_channels(*(*C.uint64_t)(unsafe.Pointer(&p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetDefaultChannelLayout(p0 int32) int64 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_get_default_channel_layout(*(*C.int)(unsafe.Pointer(&p0)))
+	return *(*int64)(unsafe.Pointer(&ret))
+}
+func GetDict(p0 *Dictionary, p1 string, p2 *DictionaryEntry, p3 int32) *DictionaryEntry {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	return (*DictionaryEntry)(unsafe.Pointer(C.dyn_av_dict_get((*C.struct_AVDictionary)(unsafe.Pointer(p0)), s1, (*C.struct_AVDictionaryEntry)(unsafe.Pointer(p2)), *(*C.int)(unsafe.Pointer(&p3)))))
+}
+func GetDisplayRotation(p0 *int32) float64 {
+	dynamicInit()
+	ret := C.dyn_av_display_rotation_get((*C.int32_t)(p0))
+	return *(*float64)(unsafe.Pointer(&ret))
+}
+func GetFrameBuffer(p0 *Frame, p1 int32) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	ret := C.dyn_av_frame_get_buffer((*C.struct_AVFrame)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func GetHWFrameBuffer(p0 *BufferRef, p1 *Frame, p2 int32) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -462,17 +898,101 @@ func GetHWFrameBuffer(p0 *BufferRef, p1 *Frame, p2 int32) int32 {
 	ret := C.dyn_av_hwframe_get_buffer((*C.struct_AVBufferRef)(unsafe.Pointer(p0)), (*C.struct_AVFrame)(unsafe.Pointer(p1)), *(*C.int)(unsafe.Pointer(&p2)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func GetOpt(p0 unsafe.Pointer, p1 string, p2 int32, p3 **uint8) int32 {
+	dynamicInit()
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.dyn_av_opt_get(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (**C.uint8_t)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptDict(p0 unsafe.Pointer, p1 string, p2 int32, p3 **Dictionary) int32 {
+	dynamicInit()
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.dyn_av_opt_get_dict_val(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (**C.struct_AVDictionary)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptDouble(p0 unsafe.Pointer, p1 string, p2 int32, p3 *float64) int32 {
+	dynamicInit()
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.dyn_av_opt_get_double(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.double)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptImageSize(p0 unsafe.Pointer, p1 string, p2 int32, p3 *int32, p4 *int32) int32 {
+	dynamicInit()
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	defer runtime.KeepAlive(p4)
+	ret := C.dyn_av_opt_get_image_size(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.int)(unsafe.Pointer(p3)), (*C.int)(unsafe.Pointer(p4)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptInt(p0 unsafe.Pointer, p1 string, p2 int32, p3 *int64) int32 {
+	dynamicInit()
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.dyn_av_opt_get_int(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.int64_t)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptRational(p0 unsafe.Pointer, p1 string, p2 int32, p3 *Rational) int32 {
+	dynamicInit()
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.dyn_av_opt_get_q(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.struct_AVRational)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func InitHWFramesContext(p0 *BufferRef) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
 	ret := C.dyn_av_hwframe_ctx_init((*C.struct_AVBufferRef)(unsafe.Pointer(p0)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func MakeFrameWritable(p0 *Frame) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	ret := C.dyn_av_frame_make_writable((*C.struct_AVFrame)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func Malloc(p0 uint64) unsafe.Pointer {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
 	return C.dyn_av_malloc(*(*C.size_t)(unsafe.Pointer(&p0)))
 }
+func MallocZeroed(p0 uint64) unsafe.Pointer {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	return C.dyn_av_mallocz(*(*C.size_t)(unsafe.Pointer(&p0)))
+}
 func MultiplyRational(p0 Rational, p1 Rational) Rational {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -484,6 +1004,12 @@ func NewFrame() *Frame {
 	dynamicInit()
 	return (*Frame)(unsafe.Pointer(C.dyn_av_frame_alloc()))
 }
+func NewFrameSideData(p0 *Frame, p1 FrameSideDataType, p2 int32) *FrameSideData {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p2)
+	return (*FrameSideData)(unsafe.Pointer(C.dyn_av_frame_new_side_data((*C.struct_AVFrame)(unsafe.Pointer(p0)), (C.uint32_t)(p1), *(*C.int)(unsafe.Pointer(&p2)))))
+}
 func NewHWDeviceContext(p0 **BufferRef, p1 HWDeviceType, p2 string, p3 *Dictionary, p4 int32) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -502,6 +1028,72 @@ func NewHWFramesContext(p0 *BufferRef) *BufferRef {
 	defer runtime.KeepAlive(p0)
 	return (*BufferRef)(unsafe.Pointer(C.dyn_av_hwframe_ctx_alloc((*C.struct_AVBufferRef)(unsafe.Pointer(p0)))))
 }
+func NextOpt(p0 unsafe.Pointer, p1 *Option) *Option {
+	dynamicInit()
+	defer runtime.KeepAlive(p1)
+	return (*Option)(unsafe.Pointer(C.dyn_av_opt_next(p0, (*C.struct_AVOption)(unsafe.Pointer(p1)))))
+}
+func NextOptChild(p0 unsafe.Pointer, p1 unsafe.Pointer) unsafe.Pointer {
+	dynamicInit()
+	return C.dyn_av_opt_child_next(p0, p1)
+}
+func ParseColor(p0 *uint8, p1 string, p2 int32, p3 unsafe.Pointer) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	ret := C.dyn_av_parse_color((*C.uint8_t)(unsafe.Pointer(p0)), s1, *(*C.int)(unsafe.Pointer(&p2)), p3)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func ParseDictString(p0 **Dictionary, p1 string, p2 string, p3 string, p4 int32) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	var s2 *C.char
+	if p2 != "" {
+		s2 = C.CString(p2)
+		defer C.free(unsafe.Pointer(s2))
+	}
+	var s3 *C.char
+	if p3 != "" {
+		s3 = C.CString(p3)
+		defer C.free(unsafe.Pointer(s3))
+	}
+	defer runtime.KeepAlive(p4)
+	ret := C.dyn_av_dict_parse_string((**C.struct_AVDictionary)(unsafe.Pointer(p0)), s1, s2, s3, *(*C.int)(unsafe.Pointer(&p4)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func ParseVideoRate(p0 *Rational, p1 string) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	ret := C.dyn_av_parse_video_rate((*C.struct_AVRational)(unsafe.Pointer(p0)), s1)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func ParseVideoSize(p0 *int32, p1 *int32, p2 string) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	var s2 *C.char
+	if p2 != "" {
+		s2 = C.CString(p2)
+		defer C.free(unsafe.Pointer(s2))
+	}
+	ret := C.dyn_av_parse_video_size((*C.int)(unsafe.Pointer(p0)), (*C.int)(unsafe.Pointer(p1)), s2)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func RefBuffer(p0 *BufferRef) *BufferRef {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -514,6 +1106,11 @@ func RefFrame(p0 *Frame, p1 *Frame) int32 {
 	ret := C.dyn_av_frame_ref((*C.struct_AVFrame)(unsafe.Pointer(p0)), (*C.struct_AVFrame)(unsafe.Pointer(p1)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func RemoveFrameSideData(p0 *Frame, p1 FrameSideDataType) {
+	dynamicInit()
+	defer runtime.KeepAlive(p0)
+	C.dyn_av_frame_remove_side_data((*C.struct_AVFrame)(unsafe.Pointer(p0)), (C.uint32_t)(p1))
+}
 func RescaleRound(p0 int64, p1 int64, p2 int64, p3 Rounding) int64 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -539,6 +1136,11 @@ func SetDict(p0 **Dictionary, p1 string, p2 string, p3 int32) int32 {
 	ret := C.dyn_av_dict_set((**C.struct_AVDictionary)(unsafe.Pointer(p0)), s1, s2, *(*C.int)(unsafe.Pointer(&p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetDisplayRotation(p0 *int32, p1 float64) {
+	dynamicInit()
+	defer runtime.KeepAlive(p1)
+	C.dyn_av_display_rotation_set((*C.int32_t)(p0), *(*C.double)(unsafe.Pointer(&p1)))
+}
 func SetOpt(p0 unsafe.Pointer, p1 string, p2 string, p3 int32) int32 {
 	dynamicInit()
 	var s1 *C.char
@@ -568,6 +1170,18 @@ func SetOptBin(p0 unsafe.Pointer, p1 string, p2 *uint8, p3 int32, p4 int32) int3
 	ret := C.dyn_av_opt_set_bin(p0, s1, (*C.uint8_t)(unsafe.Pointer(p2)), *(*C.int)(unsafe.Pointer(&p3)), *(*C.int)(unsafe.Pointer(&p4)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetOptDict(p0 unsafe.Pointer, p1 string, p2 *Dictionary, p3 int32) int32 {
+	dynamicInit()
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.dyn_av_opt_set_dict_val(p0, s1, (*C.struct_AVDictionary)(unsafe.Pointer(p2)), *(*C.int)(unsafe.Pointer(&p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func SetOptDouble(p0 unsafe.Pointer, p1 string, p2 float64, p3 int32) int32 {
 	dynamicInit()
 	var s1 *C.char
@@ -580,6 +1194,19 @@ func SetOptDouble(p0 unsafe.Pointer, p1 string, p2 float64, p3 int32) int32 {
 	ret := C.dyn_av_opt_set_double(p0, s1, *(*C.double)(unsafe.Pointer(&p2)), *(*C.int)(unsafe.Pointer(&p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetOptImageSize(p0 unsafe.Pointer, p1 string, p2 int32, p3 int32, p4 int32) int32 {
+	dynamicInit()
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	defer runtime.KeepAlive(p4)
+	ret := C.dyn_av_opt_set_image_size(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), *(*C.int)(unsafe.Pointer(&p3)), *(*C.int)(unsafe.Pointer(&p4)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func SetOptInt(p0 unsafe.Pointer, p1 string, p2 int64, p3 int32) int32 {
 	dynamicInit()
 	var s1 *C.char
@@ -615,6 +1242,17 @@ func SetOptRational(p0 unsafe.Pointer, p1 string, p2 Rational, p3 int32) int32 {
 	ret := C.dyn_av_opt_set_q(p0, s1, *(*C.struct_AVRational)(unsafe.Pointer(&p2)), *(*C.int)(unsafe.Pointer(&p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetOptSampleFormat(p0 unsafe.Pointer, p1 string, p2 SampleFormat, p3 int32) int32 {
+	dynamicInit()
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p3)
+	ret := C.dyn_av_opt_set_sample_fmt(p0, s1, (C.int32_t)(p2), *(*C.int)(unsafe.Pointer(&p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func TransferHWFrameData(p0 *Frame, p1 *Frame, p2 int32) int32 {
 	dynamicInit()
 	defer runtime.KeepAlive(p0)
@@ -633,10 +1271,27 @@ func UnrefFrame(p0 *Frame) {
 	defer runtime.KeepAlive(p0)
 	C.dyn_av_frame_unref((*C.struct_AVFrame)(unsafe.Pointer(p0)))
 }
+func countPixelFormatPlanes(p0 PixelFormat) int32 {
+	dynamicInit()
+	ret := C.dyn_av_pix_fmt_count_planes((C.int32_t)(p0))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func getBytesPerSample(p0 SampleFormat) int32 {
+	dynamicInit()
+	ret := C.dyn_av_get_bytes_per_sample((C.int32_t)(p0))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func getChromaLocationName(p0 uint32) *common.CChar {
 	dynamicInit()
 	return (*common.CChar)(unsafe.Pointer(C.dyn_av_chroma_location_name(p0)))
 }
+func getChromaSubsample(p0 PixelFormat, p1 *int32, p2 *int32) int32 {
+	dynamicInit()
+	defer runtime.KeepAlive(p1)
+	defer runtime.KeepAlive(p2)
+	ret := C.dyn_av_pix_fmt_get_chroma_sub_sample((C.int32_t)(p0), (*C.int)(unsafe.Pointer(p1)), (*C.int)(unsafe.Pointer(p2)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func getColorPrimariesName(p0 uint32) *common.CChar {
 	dynamicInit()
 	return (*common.CChar)(unsafe.Pointer(C.dyn_av_color_primaries_name(p0)))
@@ -653,6 +1308,10 @@ func getColorTransferName(p0 uint32) *common.CChar {
 	dynamicInit()
 	return (*common.CChar)(unsafe.Pointer(C.dyn_av_color_transfer_name(p0)))
 }
+func getFrameSideDataName(p0 FrameSideDataType) *common.CChar {
+	dynamicInit()
+	return (*common.CChar)(unsafe.Pointer(C.dyn_av_frame_side_data_name((C.uint32_t)(p0))))
+}
 func getHWDeviceTypeName(p0 HWDeviceType) *common.CChar {
 	dynamicInit()
 	return (*common.CChar)(unsafe.Pointer(C.dyn_av_hwdevice_get_type_name((C.uint32_t)(p0))))
@@ -661,11 +1320,40 @@ func getMediaTypeString(p0 MediaType) *common.CChar {
 	dynamicInit()
 	return (*common.CChar)(unsafe.Pointer(C.dyn_av_get_media_type_string((C.int32_t)(p0))))
 }
+func getPixelFormat(p0 string) int32 {
+	dynamicInit()
+	var s0 *C.char
+	if p0 != "" {
+		s0 = C.CString(p0)
+		defer C.free(unsafe.Pointer(s0))
+	}
+	ret := C.dyn_av_get_pix_fmt(s0)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func getPixelFormatDescriptor(p0 PixelFormat) *PixFmtDescriptor {
+	dynamicInit()
+	return (*PixFmtDescriptor)(unsafe.Pointer(C.dyn_av_pix_fmt_desc_get((C.int32_t)(p0))))
+}
 func getPixelFormatName(p0 PixelFormat) *common.CChar {
 	dynamicInit()
 	return (*common.CChar)(unsafe.Pointer(C.dyn_av_get_pix_fmt_name((C.int32_t)(p0))))
 }
+func getSampleFormat(p0 string) int32 {
+	dynamicInit()
+	var s0 *C.char
+	if p0 != "" {
+		s0 = C.CString(p0)
+		defer C.free(unsafe.Pointer(s0))
+	}
+	ret := C.dyn_av_get_sample_fmt(s0)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func getSampleFormatName(p0 SampleFormat) *common.CChar {
 	dynamicInit()
 	return (*common.CChar)(unsafe.Pointer(C.dyn_av_get_sample_fmt_name((C.int32_t)(p0))))
 }
+func isPlanarSampleFormat(p0 SampleFormat) int32 {
+	dynamicInit()
+	ret := C.dyn_av_sample_fmt_is_planar((C.int32_t)(p0))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
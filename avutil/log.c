@@ -1,16 +1,57 @@
 #include <stdarg.h>
+#include <string.h>
 
 #define LINE_SZ 1024
 
-extern void goavLog(int level, char *str);
+extern void goavLog(void *class_ptr, int level, char *line, int prefix_len, char *item_name);
 extern void av_log_set_callback(void (*callback)(void *, int, const char *, va_list));
 extern void av_log_format_line(void *ptr, int level, const char *fmt, va_list vl, char *line, int line_size, int *print_prefix);
 
+// goavClass mirrors the leading fields of AVClass, which are stable across
+// versions
+typedef struct {
+    const char *class_name;
+    const char *(*item_name)(void *ctx);
+} goavClass;
+
+// goavLogPrefixLength returns the length of the "[item @ 0x...]" prefix that
+// av_log_format_line puts in front of the messages of class_ptr. The prefix is
+// formatted with an empty message, so no arguments are read from vl.
+static int goavLogPrefixLength(void *class_ptr, int level, ...) {
+    char prefix[LINE_SZ];
+    int print_prefix = 1;
+    va_list vl;
+
+    va_start(vl, level);
+    av_log_format_line(class_ptr, level, "", vl, prefix, LINE_SZ, &print_prefix);
+    va_end(vl);
+
+    return strlen(prefix);
+}
+
 void goavLogCallback(void *class_ptr, int level, const char *fmt, va_list vl) {
     char line[LINE_SZ];
+    char *item_name = 0;
     int print_prefix = 1;
+    int line_len, prefix_len;
+
     av_log_format_line(class_ptr, level, fmt, vl, line, LINE_SZ, &print_prefix);
-    goavLog(level, line);
+    line_len = strlen(line);
+
+    // the message is the rest of the line, which may have been truncated
+    prefix_len = goavLogPrefixLength(class_ptr, level);
+    if (prefix_len > line_len) {
+        prefix_len = line_len;
+    }
+
+    if (class_ptr) {
+        goavClass *avc = *(goavClass **)class_ptr;
+        if (avc) {
+            item_name = (char *)(avc->item_name ? avc->item_name(class_ptr) : avc->class_name);
+        }
+    }
+
+    goavLog(class_ptr, level, line, prefix_len, item_name);
 }
 
 void goavLogSetup() {
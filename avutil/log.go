@@ -5,8 +5,14 @@ package avutil
 // void goavLogSetup();
 import "C"
 import (
+	"context"
 	"log"
+	"log/slog"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
 type VerbosityLevel int
@@ -31,18 +37,149 @@ func initLogging() {
 	})
 }
 
+// SlogLevel maps the level to a slog level. Panic and fatal messages are
+// above slog.LevelError and verbose and trace messages are between and below
+// the standard slog levels respectively.
+func (l VerbosityLevel) SlogLevel() slog.Level {
+	switch {
+	case l <= Fatal:
+		return slog.LevelError + 4
+	case l <= Errors:
+		return slog.LevelError
+	case l <= Warning:
+		return slog.LevelWarn
+	case l <= Info:
+		return slog.LevelInfo
+	case l <= Verbose:
+		return slog.LevelDebug + 2
+	case l <= Debug:
+		return slog.LevelDebug
+	}
+
+	return slog.LevelDebug - 4
+}
+
 var Logger *log.Logger
 var Verbosity = Info
 
+type logHandlerState struct {
+	handler  slog.Handler
+	resolver func(ctx unsafe.Pointer) slog.Handler
+	observer func(ctx unsafe.Pointer, level VerbosityLevel, item, message string)
+}
+
+var logHandlers atomic.Value
+
+func loadLogHandlers() logHandlerState {
+	state, _ := logHandlers.Load().(logHandlerState)
+	return state
+}
+
+var logHandlersMutex sync.Mutex
+
+// SetLogHandler routes log messages to h instead of Logger. Levels are mapped
+// with SlogLevel and filtered by h instead of Verbosity, and the item name of
+// the context that logged the message, like "h264" or "mov,mp4", is attached
+// as the "item" attribute. A nil handler restores logging to Logger.
+func SetLogHandler(h slog.Handler) {
+	logHandlersMutex.Lock()
+	defer logHandlersMutex.Unlock()
+
+	state := loadLogHandlers()
+	state.handler = h
+	logHandlers.Store(state)
+}
+
+// SetLogHandlerResolver sets a function that returns the handler for the
+// messages logged by the given context, or nil to use the handler set with
+// SetLogHandler.
+func SetLogHandlerResolver(f func(ctx unsafe.Pointer) slog.Handler) {
+	logHandlersMutex.Lock()
+	defer logHandlersMutex.Unlock()
+
+	state := loadLogHandlers()
+	state.resolver = f
+	logHandlers.Store(state)
+}
+
+// SetLogObserver sets a function that is called with every message that is
+// logged by a context, regardless of the log handler and verbosity. The
+// message does not have the trailing newline. A nil function removes the
+// observer.
+func SetLogObserver(f func(ctx unsafe.Pointer, level VerbosityLevel, item, message string)) {
+	logHandlersMutex.Lock()
+	defer logHandlersMutex.Unlock()
+
+	state := loadLogHandlers()
+	state.observer = f
+	logHandlers.Store(state)
+}
+
 //export goavLog
-func goavLog(level C.int, cstr *C.char) {
-	if level > C.int(Verbosity) {
-		return
+func goavLog(classPtr unsafe.Pointer, level C.int, cline *C.char, prefixLen C.int, itemName *C.char) {
+	state := loadLogHandlers()
+
+	handler := state.handler
+	if state.resolver != nil && classPtr != nil {
+		if h := state.resolver(classPtr); h != nil {
+			handler = h
+		}
 	}
 
-	if Logger == nil {
-		log.Print(C.GoString(cstr))
+	slogLevel := VerbosityLevel(level).SlogLevel()
+
+	var enabled bool
+	if handler == nil {
+		enabled = level <= C.int(Verbosity)
 	} else {
-		Logger.Print(C.GoString(cstr))
+		enabled = handler.Enabled(context.Background(), slogLevel)
 	}
+
+	observe := state.observer != nil && classPtr != nil
+	if !enabled && !observe {
+		return
+	}
+
+	// the line is only formatted once, the message is the line without the
+	// "[item @ 0x...]" prefix
+	line := C.GoString(cline)
+
+	// messages that do not end with a newline are continued by the next
+	// message, but there is no way to join them with slog
+	message := strings.TrimSuffix(line[prefixLen:], "\n")
+
+	var item string
+	if itemName != nil {
+		item = C.GoString(itemName)
+	}
+
+	if observe && message != "" {
+		state.observer(classPtr, VerbosityLevel(level), item, message)
+	}
+
+	if !enabled {
+		return
+	}
+
+	if handler == nil {
+		if Logger == nil {
+			log.Print(line)
+		} else {
+			Logger.Print(line)
+		}
+
+		return
+	}
+
+	if message == "" {
+		return
+	}
+
+	record := slog.NewRecord(time.Now(), slogLevel, message, 0)
+	if itemName != nil {
+		record.AddAttrs(slog.String("item", item))
+	}
+
+	// errors can not be reported to libav
+	_ = handler.Handle(context.Background(), record)
 }
@@ -7,14 +7,70 @@ import "C"
 type OptionType C.enum_AVOptionType
 
 const (
-	OptionTypeBool      = OptionType(C.AV_OPT_TYPE_BOOL)
-	OptionTypeFlags     = OptionType(C.AV_OPT_TYPE_FLAGS)
-	OptionTypeInt       = OptionType(C.AV_OPT_TYPE_INT)
-	OptionTypeInt64     = OptionType(C.AV_OPT_TYPE_INT64)
-	OptionTypeUint64    = OptionType(C.AV_OPT_TYPE_UINT64)
-	OptionTypeFloat     = OptionType(C.AV_OPT_TYPE_FLOAT)
-	OptionTypeDouble    = OptionType(C.AV_OPT_TYPE_DOUBLE)
-	OptionTypeVideoRate = OptionType(C.AV_OPT_TYPE_VIDEO_RATE)
-	OptionTypeRational  = OptionType(C.AV_OPT_TYPE_RATIONAL)
-	OptionTypeConst     = OptionType(C.AV_OPT_TYPE_CONST)
+	OptionTypeBool          = OptionType(C.AV_OPT_TYPE_BOOL)
+	OptionTypeFlags         = OptionType(C.AV_OPT_TYPE_FLAGS)
+	OptionTypeInt           = OptionType(C.AV_OPT_TYPE_INT)
+	OptionTypeInt64         = OptionType(C.AV_OPT_TYPE_INT64)
+	OptionTypeUint64        = OptionType(C.AV_OPT_TYPE_UINT64)
+	OptionTypeFloat         = OptionType(C.AV_OPT_TYPE_FLOAT)
+	OptionTypeDouble        = OptionType(C.AV_OPT_TYPE_DOUBLE)
+	OptionTypeVideoRate     = OptionType(C.AV_OPT_TYPE_VIDEO_RATE)
+	OptionTypeRational      = OptionType(C.AV_OPT_TYPE_RATIONAL)
+	OptionTypeConst         = OptionType(C.AV_OPT_TYPE_CONST)
+	OptionTypeString        = OptionType(C.AV_OPT_TYPE_STRING)
+	OptionTypeBinary        = OptionType(C.AV_OPT_TYPE_BINARY)
+	OptionTypeDict          = OptionType(C.AV_OPT_TYPE_DICT)
+	OptionTypeImageSize     = OptionType(C.AV_OPT_TYPE_IMAGE_SIZE)
+	OptionTypePixelFormat   = OptionType(C.AV_OPT_TYPE_PIXEL_FMT)
+	OptionTypeSampleFormat  = OptionType(C.AV_OPT_TYPE_SAMPLE_FMT)
+	OptionTypeDuration      = OptionType(C.AV_OPT_TYPE_DURATION)
+	OptionTypeColor         = OptionType(C.AV_OPT_TYPE_COLOR)
+	OptionTypeChannelLayout = OptionType(C.AV_OPT_TYPE_CHANNEL_LAYOUT)
+)
+
+var optionTypeNames = map[OptionType]string{
+	OptionTypeBool:          "bool",
+	OptionTypeFlags:         "flags",
+	OptionTypeInt:           "int",
+	OptionTypeInt64:         "int64",
+	OptionTypeUint64:        "uint64",
+	OptionTypeFloat:         "float",
+	OptionTypeDouble:        "double",
+	OptionTypeVideoRate:     "video_rate",
+	OptionTypeRational:      "rational",
+	OptionTypeConst:         "const",
+	OptionTypeString:        "string",
+	OptionTypeBinary:        "binary",
+	OptionTypeDict:          "dictionary",
+	OptionTypeImageSize:     "image_size",
+	OptionTypePixelFormat:   "pix_fmt",
+	OptionTypeSampleFormat:  "sample_fmt",
+	OptionTypeDuration:      "duration",
+	OptionTypeColor:         "color",
+	OptionTypeChannelLayout: "channel_layout",
+}
+
+func (t OptionType) String() string {
+	if name, ok := optionTypeNames[t]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+const (
+	OptionFlagEncodingParam  = C.AV_OPT_FLAG_ENCODING_PARAM
+	OptionFlagDecodingParam  = C.AV_OPT_FLAG_DECODING_PARAM
+	OptionFlagAudioParam     = C.AV_OPT_FLAG_AUDIO_PARAM
+	OptionFlagVideoParam     = C.AV_OPT_FLAG_VIDEO_PARAM
+	OptionFlagSubtitleParam  = C.AV_OPT_FLAG_SUBTITLE_PARAM
+	OptionFlagExport         = C.AV_OPT_FLAG_EXPORT
+	OptionFlagReadonly       = C.AV_OPT_FLAG_READONLY
+	OptionFlagBSFParam       = C.AV_OPT_FLAG_BSF_PARAM
+	OptionFlagFilteringParam = C.AV_OPT_FLAG_FILTERING_PARAM
+	OptionFlagDeprecated     = C.AV_OPT_FLAG_DEPRECATED
+)
+
+const (
+	OptionSearchChildren = C.AV_OPT_SEARCH_CHILDREN
 )
@@ -1,16 +1,93 @@
 package avutil
 
 // #include <libavutil/avutil.h>
+// #include <libavutil/pixdesc.h>
 // #include <libavutil/pixfmt.h>
 import "C"
+import (
+	"github.com/pkg/errors"
+)
 
 type PixelFormat C.enum_AVPixelFormat
 
 const (
+	PixelFormatNone = PixelFormat(C.AV_PIX_FMT_NONE)
 	PixelFormatCuda = PixelFormat(C.AV_PIX_FMT_CUDA)
 	PixelFormatNV12 = PixelFormat(C.AV_PIX_FMT_NV12)
+
+	PixelFormatYUV420P  = PixelFormat(C.AV_PIX_FMT_YUV420P)
+	PixelFormatYUVJ420P = PixelFormat(C.AV_PIX_FMT_YUVJ420P)
+	PixelFormatYUV422P  = PixelFormat(C.AV_PIX_FMT_YUV422P)
+	PixelFormatYUVJ422P = PixelFormat(C.AV_PIX_FMT_YUVJ422P)
+	PixelFormatYUV444P  = PixelFormat(C.AV_PIX_FMT_YUV444P)
+	PixelFormatYUVJ444P = PixelFormat(C.AV_PIX_FMT_YUVJ444P)
+	PixelFormatYUV440P  = PixelFormat(C.AV_PIX_FMT_YUV440P)
+	PixelFormatYUVJ440P = PixelFormat(C.AV_PIX_FMT_YUVJ440P)
+	PixelFormatYUV411P  = PixelFormat(C.AV_PIX_FMT_YUV411P)
+	PixelFormatYUV410P  = PixelFormat(C.AV_PIX_FMT_YUV410P)
+	PixelFormatGray8    = PixelFormat(C.AV_PIX_FMT_GRAY8)
+	PixelFormatRGBA     = PixelFormat(C.AV_PIX_FMT_RGBA)
+	PixelFormatRGB0     = PixelFormat(C.AV_PIX_FMT_RGB0)
 )
 
 func (f PixelFormat) String() string {
 	return getPixelFormatName(f).String()
 }
+
+// ParsePixelFormat returns the pixel format with the given name, like
+// "yuv420p" or "nv12".
+func ParsePixelFormat(name string) (PixelFormat, error) {
+	f := PixelFormat(getPixelFormat(name))
+	if f == PixelFormatNone {
+		return PixelFormatNone, errors.Errorf("unknown pixel format: %s", name)
+	}
+
+	return f, nil
+}
+
+func (f PixelFormat) MarshalText() ([]byte, error) {
+	if f == PixelFormatNone {
+		return []byte("none"), nil
+	}
+
+	name := f.String()
+	if name == "" {
+		return nil, errors.Errorf("invalid pixel format: %d", int(f))
+	}
+
+	return []byte(name), nil
+}
+
+func (f *PixelFormat) UnmarshalText(text []byte) error {
+	if string(text) == "none" {
+		*f = PixelFormatNone
+		return nil
+	}
+
+	ret, err := ParsePixelFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*f = ret
+	return nil
+}
+
+// Planes returns the number of planes of the format.
+func (f PixelFormat) Planes() int {
+	return int(countPixelFormatPlanes(f))
+}
+
+// IsRGB reports whether the format stores rgb components instead of yuv.
+func (f PixelFormat) IsRGB() bool {
+	desc := getPixelFormatDescriptor(f)
+	return desc != nil && desc.Flags&C.AV_PIX_FMT_FLAG_RGB != 0
+}
+
+// ChromaSubsample returns the log2 of the horizontal and vertical chroma
+// subsampling factors.
+func (f PixelFormat) ChromaSubsample() (int, int) {
+	var h, v int32
+	getChromaSubsample(f, &h, &v)
+	return int(h), int(v)
+}
@@ -0,0 +1,50 @@
+package avutil_test
+
+import (
+	"testing"
+
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func TestPixelFormatTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		format avutil.PixelFormat
+		text   string
+	}{
+		{avutil.PixelFormatNone, "none"},
+		{avutil.PixelFormatYUV420P, "yuv420p"},
+		{avutil.PixelFormatYUVJ444P, "yuvj444p"},
+		{avutil.PixelFormatNV12, "nv12"},
+		{avutil.PixelFormatGray8, "gray"},
+		{avutil.PixelFormatRGBA, "rgba"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			text, err := tt.format.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(text) != tt.text {
+				t.Errorf("MarshalText() = %q, want %q", text, tt.text)
+			}
+
+			var got avutil.PixelFormat
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.format {
+				t.Errorf("UnmarshalText(%q) = %s, want %s", text, got, tt.format)
+			}
+		})
+	}
+}
+
+func TestPixelFormatUnmarshalTextUnknown(t *testing.T) {
+	var f avutil.PixelFormat
+	if err := f.UnmarshalText([]byte("yuv421p")); err == nil {
+		t.Errorf("UnmarshalText(%q) = %s, want error", "yuv421p", f)
+	}
+}
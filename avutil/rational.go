@@ -2,6 +2,9 @@ package avutil
 
 import (
 	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
 func RescaleQRound(a int64, b, c Rational, flags Rounding) int64 {
@@ -24,6 +27,41 @@ func (q Rational) String() string {
 	return strconv.Itoa(int(q.Num)) + "/" + strconv.Itoa(int(q.Den))
 }
 
+// ParseRational parses a rational in the form "num/den" or "num:den", or a
+// whole number.
+func ParseRational(s string) (Rational, error) {
+	num, den := s, "1"
+	if i := strings.IndexAny(s, "/:"); i >= 0 {
+		num, den = s[:i], s[i+1:]
+	}
+
+	n, err := strconv.ParseInt(num, 10, 32)
+	if err != nil {
+		return Rational{}, errors.Errorf("invalid rational: %s", s)
+	}
+
+	d, err := strconv.ParseInt(den, 10, 32)
+	if err != nil {
+		return Rational{}, errors.Errorf("invalid rational: %s", s)
+	}
+
+	return Rat(int32(n), int32(d)), nil
+}
+
+func (q Rational) MarshalText() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+func (q *Rational) UnmarshalText(text []byte) error {
+	ret, err := ParseRational(string(text))
+	if err != nil {
+		return err
+	}
+
+	*q = ret
+	return nil
+}
+
 func (q Rational) IsZero() bool {
 	return q.Num == 0
 }
@@ -0,0 +1,75 @@
+package avutil_test
+
+import (
+	"testing"
+
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func TestParseRational(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    avutil.Rational
+		wantErr bool
+	}{
+		{in: "1/25", want: avutil.Rat(1, 25)},
+		{in: "30000:1001", want: avutil.Rat(30000, 1001)},
+		{in: "-1/2", want: avutil.Rat(-1, 2)},
+		{in: "24", want: avutil.Rat(24, 1)},
+		{in: "0/1", want: avutil.Rat(0, 1)},
+		{in: "", wantErr: true},
+		{in: "1/", wantErr: true},
+		{in: "/2", wantErr: true},
+		{in: "1.5", wantErr: true},
+		{in: "1/2/3", wantErr: true},
+		{in: "4294967296/1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := avutil.ParseRational(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRational(%q) = %s, want error", tt.in, got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseRational(%q) returned error: %v", tt.in, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseRational(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRationalTextRoundTrip(t *testing.T) {
+	tests := []avutil.Rational{
+		avutil.Rat(1, 25),
+		avutil.Rat(30000, 1001),
+		avutil.Rat(-1, 2),
+		avutil.Rat(0, 1),
+	}
+
+	for _, q := range tests {
+		t.Run(q.String(), func(t *testing.T) {
+			text, err := q.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got avutil.Rational
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatal(err)
+			}
+
+			if got != q {
+				t.Errorf("round trip of %s = %s", q, got)
+			}
+		})
+	}
+}
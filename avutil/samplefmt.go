@@ -3,6 +3,9 @@ package avutil
 // #include <libavutil/avutil.h>
 // #include <libavutil/samplefmt.h>
 import "C"
+import (
+	"github.com/pkg/errors"
+)
 
 type SampleFormat C.enum_AVSampleFormat
 
@@ -25,3 +28,50 @@ const (
 func (f SampleFormat) String() string {
 	return getSampleFormatName(f).String()
 }
+
+// ParseSampleFormat returns the sample format with the given name, like "s16"
+// or "fltp".
+func ParseSampleFormat(name string) (SampleFormat, error) {
+	f := SampleFormat(getSampleFormat(name))
+	if f == SampleFormatNone {
+		return SampleFormatNone, errors.Errorf("unknown sample format: %s", name)
+	}
+
+	return f, nil
+}
+
+func (f SampleFormat) MarshalText() ([]byte, error) {
+	if f == SampleFormatNone {
+		return []byte("none"), nil
+	}
+
+	name := f.String()
+	if name == "" {
+		return nil, errors.Errorf("invalid sample format: %d", int(f))
+	}
+
+	return []byte(name), nil
+}
+
+func (f *SampleFormat) UnmarshalText(text []byte) error {
+	if string(text) == "none" {
+		*f = SampleFormatNone
+		return nil
+	}
+
+	ret, err := ParseSampleFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*f = ret
+	return nil
+}
+
+func (f SampleFormat) BytesPerSample() int {
+	return int(getBytesPerSample(f))
+}
+
+func (f SampleFormat) IsPlanar() bool {
+	return isPlanarSampleFormat(f) != 0
+}
@@ -0,0 +1,49 @@
+package avutil_test
+
+import (
+	"testing"
+
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func TestSampleFormatTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		format avutil.SampleFormat
+		text   string
+	}{
+		{avutil.SampleFormatNone, "none"},
+		{avutil.SampleFormatU8, "u8"},
+		{avutil.SampleFormatS16, "s16"},
+		{avutil.SampleFormatFLTP, "fltp"},
+		{avutil.SampleFormatS64P, "s64p"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			text, err := tt.format.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(text) != tt.text {
+				t.Errorf("MarshalText() = %q, want %q", text, tt.text)
+			}
+
+			var got avutil.SampleFormat
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.format {
+				t.Errorf("UnmarshalText(%q) = %s, want %s", text, got, tt.format)
+			}
+		})
+	}
+}
+
+func TestSampleFormatUnmarshalTextUnknown(t *testing.T) {
+	var f avutil.SampleFormat
+	if err := f.UnmarshalText([]byte("s24")); err == nil {
+		t.Errorf("UnmarshalText(%q) = %s, want error", "s24", f)
+	}
+}
@@ -0,0 +1,26 @@
+package avutil
+
+// #include <libavutil/frame.h>
+import "C"
+
+type FrameSideDataType C.enum_AVFrameSideDataType
+
+const (
+	FrameSideDataPanScan                  = FrameSideDataType(C.AV_FRAME_DATA_PANSCAN)
+	FrameSideDataA53CC                    = FrameSideDataType(C.AV_FRAME_DATA_A53_CC)
+	FrameSideDataStereo3D                 = FrameSideDataType(C.AV_FRAME_DATA_STEREO3D)
+	FrameSideDataReplayGain               = FrameSideDataType(C.AV_FRAME_DATA_REPLAYGAIN)
+	FrameSideDataDisplayMatrix            = FrameSideDataType(C.AV_FRAME_DATA_DISPLAYMATRIX)
+	FrameSideDataAFD                      = FrameSideDataType(C.AV_FRAME_DATA_AFD)
+	FrameSideDataMotionVectors            = FrameSideDataType(C.AV_FRAME_DATA_MOTION_VECTORS)
+	FrameSideDataSkipSamples              = FrameSideDataType(C.AV_FRAME_DATA_SKIP_SAMPLES)
+	FrameSideDataMasteringDisplayMetadata = FrameSideDataType(C.AV_FRAME_DATA_MASTERING_DISPLAY_METADATA)
+	FrameSideDataSpherical                = FrameSideDataType(C.AV_FRAME_DATA_SPHERICAL)
+	FrameSideDataContentLightLevel        = FrameSideDataType(C.AV_FRAME_DATA_CONTENT_LIGHT_LEVEL)
+	FrameSideDataICCProfile               = FrameSideDataType(C.AV_FRAME_DATA_ICC_PROFILE)
+	FrameSideDataSEIUnregistered          = FrameSideDataType(C.AV_FRAME_DATA_SEI_UNREGISTERED)
+)
+
+func (t FrameSideDataType) String() string {
+	return getFrameSideDataName(t).String()
+}
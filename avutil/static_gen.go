@@ -13,21 +13,48 @@ import (
 /*
 #include <libavutil/avutil.h>
 #include <libavutil/buffer.h>
+#include <libavutil/channel_layout.h>
 #include <libavutil/dict.h>
+#include <libavutil/display.h>
 #include <libavutil/frame.h>
 #include <libavutil/pixdesc.h>
 #include <libavutil/hwcontext.h>
 #include <libavutil/log.h>
+#include <libavutil/mastering_display_metadata.h>
 #include <libavutil/opt.h>
+#include <libavutil/parseutils.h>
+#include <libavutil/samplefmt.h>
 */
 import "C"
 
+func AddDynarray(p0 unsafe.Pointer, p1 *int32, p2 unsafe.Pointer) int32 {
+	defer runtime.KeepAlive(p1)
+	ret := C.av_dynarray_add_nofree(p0, (*C.int)(unsafe.Pointer(p1)), p2)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func CopyFrameProps(p0 *Frame, p1 *Frame) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
 	ret := C.av_frame_copy_props((*C.struct_AVFrame)(unsafe.Pointer(p0)), (*C.struct_AVFrame)(unsafe.Pointer(p1)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func CountDict(p0 *Dictionary) int32 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_dict_count((*C.struct_AVDictionary)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func CreateBuffer(p0 *uint8, p1 int32, p2 unsafe.Pointer, p3 unsafe.Pointer, p4 int32) *BufferRef {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	defer runtime.KeepAlive(p4)
+	return (*BufferRef)(unsafe.Pointer(C.av_buffer_create((*C.uint8_t)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)), (*[0]byte)(p2), p3, *(*C.int)(unsafe.Pointer(&p4)))))
+}
+func DoubleToRational(p0 float64, p1 int32) Rational {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	ret := C.av_d2q(*(*C.double)(unsafe.Pointer(&p0)), *(*C.int)(unsafe.Pointer(&p1)))
+	return *(*Rational)(unsafe.Pointer(&ret))
+}
 func DupeString(p0 string) *common.CChar {
 	var s0 *C.char
 	if p0 != "" {
@@ -62,6 +89,37 @@ func FreeFrame(p0 **Frame) {
 	defer runtime.KeepAlive(p0)
 	C.av_frame_free((**C.struct_AVFrame)(unsafe.Pointer(p0)))
 }
+func GetChannelLayoutNbChannels(p0 uint64) int32 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_get_channel_layout_nb_channels(*(*C.uint64_t)(unsafe.Pointer(&p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetDefaultChannelLayout(p0 int32) int64 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_get_default_channel_layout(*(*C.int)(unsafe.Pointer(&p0)))
+	return *(*int64)(unsafe.Pointer(&ret))
+}
+func GetDict(p0 *Dictionary, p1 string, p2 *DictionaryEntry, p3 int32) *DictionaryEntry {
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	return (*DictionaryEntry)(unsafe.Pointer(C.av_dict_get((*C.struct_AVDictionary)(unsafe.Pointer(p0)), s1, (*C.struct_AVDictionaryEntry)(unsafe.Pointer(p2)), *(*C.int)(unsafe.Pointer(&p3)))))
+}
+func GetDisplayRotation(p0 *int32) float64 {
+	ret := C.av_display_rotation_get((*C.int32_t)(p0))
+	return *(*float64)(unsafe.Pointer(&ret))
+}
+func GetFrameBuffer(p0 *Frame, p1 int32) int32 {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	ret := C.av_frame_get_buffer((*C.struct_AVFrame)(unsafe.Pointer(p0)), *(*C.int)(unsafe.Pointer(&p1)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func GetHWFrameBuffer(p0 *BufferRef, p1 *Frame, p2 int32) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -69,15 +127,91 @@ func GetHWFrameBuffer(p0 *BufferRef, p1 *Frame, p2 int32) int32 {
 	ret := C.av_hwframe_get_buffer((*C.struct_AVBufferRef)(unsafe.Pointer(p0)), (*C.struct_AVFrame)(unsafe.Pointer(p1)), *(*C.int)(unsafe.Pointer(&p2)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func GetOpt(p0 unsafe.Pointer, p1 string, p2 int32, p3 **uint8) int32 {
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.av_opt_get(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (**C.uint8_t)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptDict(p0 unsafe.Pointer, p1 string, p2 int32, p3 **Dictionary) int32 {
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.av_opt_get_dict_val(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (**C.struct_AVDictionary)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptDouble(p0 unsafe.Pointer, p1 string, p2 int32, p3 *float64) int32 {
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.av_opt_get_double(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.double)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptImageSize(p0 unsafe.Pointer, p1 string, p2 int32, p3 *int32, p4 *int32) int32 {
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	defer runtime.KeepAlive(p4)
+	ret := C.av_opt_get_image_size(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.int)(unsafe.Pointer(p3)), (*C.int)(unsafe.Pointer(p4)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptInt(p0 unsafe.Pointer, p1 string, p2 int32, p3 *int64) int32 {
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.av_opt_get_int(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.int64_t)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func GetOptRational(p0 unsafe.Pointer, p1 string, p2 int32, p3 *Rational) int32 {
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.av_opt_get_q(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), (*C.struct_AVRational)(unsafe.Pointer(p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func InitHWFramesContext(p0 *BufferRef) int32 {
 	defer runtime.KeepAlive(p0)
 	ret := C.av_hwframe_ctx_init((*C.struct_AVBufferRef)(unsafe.Pointer(p0)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func MakeFrameWritable(p0 *Frame) int32 {
+	defer runtime.KeepAlive(p0)
+	ret := C.av_frame_make_writable((*C.struct_AVFrame)(unsafe.Pointer(p0)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func Malloc(p0 uint64) unsafe.Pointer {
 	defer runtime.KeepAlive(p0)
 	return C.av_malloc(*(*C.size_t)(unsafe.Pointer(&p0)))
 }
+func MallocZeroed(p0 uint64) unsafe.Pointer {
+	defer runtime.KeepAlive(p0)
+	return C.av_mallocz(*(*C.size_t)(unsafe.Pointer(&p0)))
+}
 func MultiplyRational(p0 Rational, p1 Rational) Rational {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -87,6 +221,11 @@ func MultiplyRational(p0 Rational, p1 Rational) Rational {
 func NewFrame() *Frame {
 	return (*Frame)(unsafe.Pointer(C.av_frame_alloc()))
 }
+func NewFrameSideData(p0 *Frame, p1 FrameSideDataType, p2 int32) *FrameSideData {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p2)
+	return (*FrameSideData)(unsafe.Pointer(C.av_frame_new_side_data((*C.struct_AVFrame)(unsafe.Pointer(p0)), (uint32)(p1), *(*C.int)(unsafe.Pointer(&p2)))))
+}
 func NewHWDeviceContext(p0 **BufferRef, p1 HWDeviceType, p2 string, p3 *Dictionary, p4 int32) int32 {
 	defer runtime.KeepAlive(p0)
 	var s2 *C.char
@@ -103,6 +242,66 @@ func NewHWFramesContext(p0 *BufferRef) *BufferRef {
 	defer runtime.KeepAlive(p0)
 	return (*BufferRef)(unsafe.Pointer(C.av_hwframe_ctx_alloc((*C.struct_AVBufferRef)(unsafe.Pointer(p0)))))
 }
+func NextOpt(p0 unsafe.Pointer, p1 *Option) *Option {
+	defer runtime.KeepAlive(p1)
+	return (*Option)(unsafe.Pointer(C.av_opt_next(p0, (*C.struct_AVOption)(unsafe.Pointer(p1)))))
+}
+func NextOptChild(p0 unsafe.Pointer, p1 unsafe.Pointer) unsafe.Pointer {
+	return C.av_opt_child_next(p0, p1)
+}
+func ParseColor(p0 *uint8, p1 string, p2 int32, p3 unsafe.Pointer) int32 {
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	ret := C.av_parse_color((*C.uint8_t)(unsafe.Pointer(p0)), s1, *(*C.int)(unsafe.Pointer(&p2)), p3)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func ParseDictString(p0 **Dictionary, p1 string, p2 string, p3 string, p4 int32) int32 {
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	var s2 *C.char
+	if p2 != "" {
+		s2 = C.CString(p2)
+		defer C.free(unsafe.Pointer(s2))
+	}
+	var s3 *C.char
+	if p3 != "" {
+		s3 = C.CString(p3)
+		defer C.free(unsafe.Pointer(s3))
+	}
+	defer runtime.KeepAlive(p4)
+	ret := C.av_dict_parse_string((**C.struct_AVDictionary)(unsafe.Pointer(p0)), s1, s2, s3, *(*C.int)(unsafe.Pointer(&p4)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func ParseVideoRate(p0 *Rational, p1 string) int32 {
+	defer runtime.KeepAlive(p0)
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	ret := C.av_parse_video_rate((*C.struct_AVRational)(unsafe.Pointer(p0)), s1)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func ParseVideoSize(p0 *int32, p1 *int32, p2 string) int32 {
+	defer runtime.KeepAlive(p0)
+	defer runtime.KeepAlive(p1)
+	var s2 *C.char
+	if p2 != "" {
+		s2 = C.CString(p2)
+		defer C.free(unsafe.Pointer(s2))
+	}
+	ret := C.av_parse_video_size((*C.int)(unsafe.Pointer(p0)), (*C.int)(unsafe.Pointer(p1)), s2)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func RefBuffer(p0 *BufferRef) *BufferRef {
 	defer runtime.KeepAlive(p0)
 	return (*BufferRef)(unsafe.Pointer(C.av_buffer_ref((*C.struct_AVBufferRef)(unsafe.Pointer(p0)))))
@@ -113,6 +312,10 @@ func RefFrame(p0 *Frame, p1 *Frame) int32 {
 	ret := C.av_frame_ref((*C.struct_AVFrame)(unsafe.Pointer(p0)), (*C.struct_AVFrame)(unsafe.Pointer(p1)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func RemoveFrameSideData(p0 *Frame, p1 FrameSideDataType) {
+	defer runtime.KeepAlive(p0)
+	C.av_frame_remove_side_data((*C.struct_AVFrame)(unsafe.Pointer(p0)), (uint32)(p1))
+}
 func RescaleRound(p0 int64, p1 int64, p2 int64, p3 Rounding) int64 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -136,6 +339,10 @@ func SetDict(p0 **Dictionary, p1 string, p2 string, p3 int32) int32 {
 	ret := C.av_dict_set((**C.struct_AVDictionary)(unsafe.Pointer(p0)), s1, s2, *(*C.int)(unsafe.Pointer(&p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetDisplayRotation(p0 *int32, p1 float64) {
+	defer runtime.KeepAlive(p1)
+	C.av_display_rotation_set((*C.int32_t)(p0), *(*C.double)(unsafe.Pointer(&p1)))
+}
 func SetOpt(p0 unsafe.Pointer, p1 string, p2 string, p3 int32) int32 {
 	var s1 *C.char
 	if p1 != "" {
@@ -163,6 +370,17 @@ func SetOptBin(p0 unsafe.Pointer, p1 string, p2 *uint8, p3 int32, p4 int32) int3
 	ret := C.av_opt_set_bin(p0, s1, (*C.uint8_t)(unsafe.Pointer(p2)), *(*C.int)(unsafe.Pointer(&p3)), *(*C.int)(unsafe.Pointer(&p4)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetOptDict(p0 unsafe.Pointer, p1 string, p2 *Dictionary, p3 int32) int32 {
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	ret := C.av_opt_set_dict_val(p0, s1, (*C.struct_AVDictionary)(unsafe.Pointer(p2)), *(*C.int)(unsafe.Pointer(&p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func SetOptDouble(p0 unsafe.Pointer, p1 string, p2 float64, p3 int32) int32 {
 	var s1 *C.char
 	if p1 != "" {
@@ -174,6 +392,18 @@ func SetOptDouble(p0 unsafe.Pointer, p1 string, p2 float64, p3 int32) int32 {
 	ret := C.av_opt_set_double(p0, s1, *(*C.double)(unsafe.Pointer(&p2)), *(*C.int)(unsafe.Pointer(&p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetOptImageSize(p0 unsafe.Pointer, p1 string, p2 int32, p3 int32, p4 int32) int32 {
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p2)
+	defer runtime.KeepAlive(p3)
+	defer runtime.KeepAlive(p4)
+	ret := C.av_opt_set_image_size(p0, s1, *(*C.int)(unsafe.Pointer(&p2)), *(*C.int)(unsafe.Pointer(&p3)), *(*C.int)(unsafe.Pointer(&p4)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func SetOptInt(p0 unsafe.Pointer, p1 string, p2 int64, p3 int32) int32 {
 	var s1 *C.char
 	if p1 != "" {
@@ -206,6 +436,16 @@ func SetOptRational(p0 unsafe.Pointer, p1 string, p2 Rational, p3 int32) int32 {
 	ret := C.av_opt_set_q(p0, s1, *(*C.struct_AVRational)(unsafe.Pointer(&p2)), *(*C.int)(unsafe.Pointer(&p3)))
 	return *(*int32)(unsafe.Pointer(&ret))
 }
+func SetOptSampleFormat(p0 unsafe.Pointer, p1 string, p2 SampleFormat, p3 int32) int32 {
+	var s1 *C.char
+	if p1 != "" {
+		s1 = C.CString(p1)
+		defer C.free(unsafe.Pointer(s1))
+	}
+	defer runtime.KeepAlive(p3)
+	ret := C.av_opt_set_sample_fmt(p0, s1, (int32)(p2), *(*C.int)(unsafe.Pointer(&p3)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func TransferHWFrameData(p0 *Frame, p1 *Frame, p2 int32) int32 {
 	defer runtime.KeepAlive(p0)
 	defer runtime.KeepAlive(p1)
@@ -221,9 +461,23 @@ func UnrefFrame(p0 *Frame) {
 	defer runtime.KeepAlive(p0)
 	C.av_frame_unref((*C.struct_AVFrame)(unsafe.Pointer(p0)))
 }
+func countPixelFormatPlanes(p0 PixelFormat) int32 {
+	ret := C.av_pix_fmt_count_planes((int32)(p0))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func getBytesPerSample(p0 SampleFormat) int32 {
+	ret := C.av_get_bytes_per_sample((int32)(p0))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func getChromaLocationName(p0 uint32) *common.CChar {
 	return (*common.CChar)(unsafe.Pointer(C.av_chroma_location_name(p0)))
 }
+func getChromaSubsample(p0 PixelFormat, p1 *int32, p2 *int32) int32 {
+	defer runtime.KeepAlive(p1)
+	defer runtime.KeepAlive(p2)
+	ret := C.av_pix_fmt_get_chroma_sub_sample((int32)(p0), (*C.int)(unsafe.Pointer(p1)), (*C.int)(unsafe.Pointer(p2)))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func getColorPrimariesName(p0 uint32) *common.CChar {
 	return (*common.CChar)(unsafe.Pointer(C.av_color_primaries_name(p0)))
 }
@@ -236,15 +490,43 @@ func getColorSpaceName(p0 uint32) *common.CChar {
 func getColorTransferName(p0 uint32) *common.CChar {
 	return (*common.CChar)(unsafe.Pointer(C.av_color_transfer_name(p0)))
 }
+func getFrameSideDataName(p0 FrameSideDataType) *common.CChar {
+	return (*common.CChar)(unsafe.Pointer(C.av_frame_side_data_name((uint32)(p0))))
+}
 func getHWDeviceTypeName(p0 HWDeviceType) *common.CChar {
 	return (*common.CChar)(unsafe.Pointer(C.av_hwdevice_get_type_name((uint32)(p0))))
 }
 func getMediaTypeString(p0 MediaType) *common.CChar {
 	return (*common.CChar)(unsafe.Pointer(C.av_get_media_type_string((int32)(p0))))
 }
+func getPixelFormat(p0 string) int32 {
+	var s0 *C.char
+	if p0 != "" {
+		s0 = C.CString(p0)
+		defer C.free(unsafe.Pointer(s0))
+	}
+	ret := C.av_get_pix_fmt(s0)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
+func getPixelFormatDescriptor(p0 PixelFormat) *PixFmtDescriptor {
+	return (*PixFmtDescriptor)(unsafe.Pointer(C.av_pix_fmt_desc_get((int32)(p0))))
+}
 func getPixelFormatName(p0 PixelFormat) *common.CChar {
 	return (*common.CChar)(unsafe.Pointer(C.av_get_pix_fmt_name((int32)(p0))))
 }
+func getSampleFormat(p0 string) int32 {
+	var s0 *C.char
+	if p0 != "" {
+		s0 = C.CString(p0)
+		defer C.free(unsafe.Pointer(s0))
+	}
+	ret := C.av_get_sample_fmt(s0)
+	return *(*int32)(unsafe.Pointer(&ret))
+}
 func getSampleFormatName(p0 SampleFormat) *common.CChar {
 	return (*common.CChar)(unsafe.Pointer(C.av_get_sample_fmt_name((int32)(p0))))
 }
+func isPlanarSampleFormat(p0 SampleFormat) int32 {
+	ret := C.av_sample_fmt_is_planar((int32)(p0))
+	return *(*int32)(unsafe.Pointer(&ret))
+}
@@ -1,10 +1,12 @@
 package av
 
 import (
-	"errors"
+	"io"
 	"runtime"
 	"sync"
+	"unsafe"
 
+	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avcodec"
 	"github.com/ssttevee/go-av/avutil"
 )
@@ -28,6 +30,53 @@ func FindBitstreamFilterByName(name string) (*BitstreamFilter, error) {
 	return &BitstreamFilter{filter: filter}, nil
 }
 
+func (f *BitstreamFilter) Name() string {
+	return f.filter.Name.String()
+}
+
+// CodecIDs returns the codecs the filter supports, or nil if it supports any
+// codec.
+func (f *BitstreamFilter) CodecIDs() []avcodec.ID {
+	if f.filter.CodecIDs == nil {
+		return nil
+	}
+
+	var ids []avcodec.ID
+	for ptr := uintptr(unsafe.Pointer(f.filter.CodecIDs)); *(*avcodec.ID)(unsafe.Pointer(ptr)) != avcodec.None; ptr += unsafe.Sizeof(avcodec.ID(0)) {
+		ids = append(ids, *(*avcodec.ID)(unsafe.Pointer(ptr)))
+	}
+
+	return ids
+}
+
+// BitstreamFilterIterator iterates over the bitstream filters in libavcodec.
+// The zero value is ready to use.
+type BitstreamFilterIterator struct {
+	opaque unsafe.Pointer
+}
+
+// Next returns the next bitstream filter, or nil once all bitstream filters
+// have been returned.
+func (it *BitstreamFilterIterator) Next() *BitstreamFilter {
+	filter := avcodec.IterateBitstreamFilters(&it.opaque)
+	if filter == nil {
+		return nil
+	}
+
+	return &BitstreamFilter{filter: filter}
+}
+
+// BitstreamFilters returns all bitstream filters in libavcodec.
+func BitstreamFilters() []*BitstreamFilter {
+	var filters []*BitstreamFilter
+	var it BitstreamFilterIterator
+	for filter := it.Next(); filter != nil; filter = it.Next() {
+		filters = append(filters, filter)
+	}
+
+	return filters
+}
+
 type BitstreamFilterContext struct {
 	ctx *avcodec.BitstreamFilterContext
 
@@ -43,29 +92,119 @@ func NewBitstreamFilterContext(filter *BitstreamFilter) (*BitstreamFilterContext
 
 	ret := &BitstreamFilterContext{ctx: ctx}
 
-	runtime.SetFinalizer(ret, func(ctx *BitstreamFilterContext) {
-		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
-		bsfCtx := ctx.ctx
-		avcodec.FreeBitstreamFilter(&bsfCtx)
-		ctx.ctx = bsfCtx
-	})
+	trackPointer(BitstreamFilterContextObject, unsafe.Pointer(ctx))
+
+	runtime.SetFinalizer(ret, freeBitstreamFilterContext)
 
 	return ret, nil
 }
 
+func freeBitstreamFilterContext(ctx *BitstreamFilterContext) {
+	untrackPointer(BitstreamFilterContextObject, unsafe.Pointer(ctx.ctx))
+	// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+	bsfCtx := ctx.ctx
+	avcodec.FreeBitstreamFilter(&bsfCtx)
+	ctx.ctx = bsfCtx
+}
+
+// Free releases the context without waiting for the garbage collector. It is
+// safe to call Free more than once and using the context afterwards returns
+// ErrClosed.
+func (ctx *BitstreamFilterContext) Free() {
+	runtime.SetFinalizer(ctx, nil)
+	freeBitstreamFilterContext(ctx)
+}
+
+func (ctx *BitstreamFilterContext) freed() bool {
+	return ctx.ctx == nil
+}
+
 func (ctx *BitstreamFilterContext) SetInputCodecParameters(params *CodecParameters) {
+	if ctx.freed() {
+		return
+	}
+
 	if err := averror(avcodec.CopyParameters(ctx.ctx.ParIn, params._codecParameters)); err != nil {
 		panic(err)
 	}
 }
 
 func (ctx *BitstreamFilterContext) SetOutputCodecParameters(params *CodecParameters) {
+	if ctx.freed() {
+		return
+	}
+
 	if err := averror(avcodec.CopyParameters(ctx.ctx.ParOut, params._codecParameters)); err != nil {
 		panic(err)
 	}
 }
 
+func (ctx *BitstreamFilterContext) SetInputTimeBase(timeBase avutil.Rational) {
+	if ctx.freed() {
+		return
+	}
+
+	ctx.ctx.TimeBaseIn = timeBase
+}
+
+// OutputCodecParameters returns the parameters of the filtered packets. It is
+// only valid after the filter is initialized, and nil once it is freed.
+func (ctx *BitstreamFilterContext) OutputCodecParameters() *CodecParameters {
+	if ctx.freed() {
+		return nil
+	}
+
+	return &CodecParameters{
+		_codecParameters: ctx.ctx.ParOut,
+	}
+}
+
+// OutputTimeBase returns the time base of the filtered packets. It is only
+// valid after the filter is initialized.
+func (ctx *BitstreamFilterContext) OutputTimeBase() avutil.Rational {
+	if ctx.freed() {
+		return avutil.Rational{}
+	}
+
+	return ctx.ctx.TimeBaseOut
+}
+
+// SetOption sets an option of the filter. Options must be set before the
+// filter is initialized.
+func (ctx *BitstreamFilterContext) SetOption(name string, value interface{}) error {
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	return setOption(unsafe.Pointer(ctx.ctx), name, value, avutil.OptionSearchChildren)
+}
+
+func (ctx *BitstreamFilterContext) GetOption(name string) (interface{}, error) {
+	if ctx.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
+	return getOption(unsafe.Pointer(ctx.ctx), name, avutil.OptionSearchChildren)
+}
+
+// Options returns the options of the filter.
+func (ctx *BitstreamFilterContext) Options() []OptionInfo {
+	if ctx.freed() {
+		return nil
+	}
+
+	return listOptions(unsafe.Pointer(ctx.ctx))
+}
+
+func (ctx *BitstreamFilterContext) Init() error {
+	return ctx.init()
+}
+
 func (ctx *BitstreamFilterContext) init() error {
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	ctx.initOnce.Do(func() {
 		if ctx.initErr = averror(avcodec.InitBitstreamFilter(ctx.ctx)); ctx.initErr != nil {
 			return
@@ -76,22 +215,50 @@ func (ctx *BitstreamFilterContext) init() error {
 }
 
 func (ctx *BitstreamFilterContext) FilterPacket(inPacket *Packet) ([]*Packet, error) {
+	return ctx.filterPacket(inPacket, nil, NewPacket, (*Packet).Free)
+}
+
+// FilterPacketWithPool is like FilterPacket, but the filtered packets are
+// taken from pool and appended to packets. Passing the result of the previous
+// call with a length of zero avoids allocating a new slice for every packet.
+func (ctx *BitstreamFilterContext) FilterPacketWithPool(inPacket *Packet, pool *PacketPool, packets []*Packet) ([]*Packet, error) {
+	return ctx.filterPacket(inPacket, packets, pool.Get, pool.Put)
+}
+
+func (ctx *BitstreamFilterContext) filterPacket(inPacket *Packet, outPackets []*Packet, newPacket func() *Packet, releasePacket func(*Packet)) ([]*Packet, error) {
 	if err := ctx.init(); err != nil {
 		return nil, err
 	}
 
-	if err := averror(avcodec.SendBitstreamFilterPacket(ctx.ctx, inPacket._packet)); err != nil {
+	defer runtime.KeepAlive(inPacket)
+
+	// a nil packet signals the end of the stream and flushes the filter
+	var pkt *avcodec.Packet
+	if inPacket != nil {
+		if inPacket.freed() {
+			return nil, errors.WithStack(ErrClosed)
+		}
+
+		pkt = inPacket._packet
+	}
+
+	if err := averror(avcodec.SendBitstreamFilterPacket(ctx.ctx, pkt)); err != nil {
 		return nil, err
 	}
 
-	var outPackets []*Packet
+	n := len(outPackets)
 	for {
-		outPacket := NewPacket()
-		if err := averror(avcodec.ReceiveBitstreamFilterPacket(ctx.ctx, outPacket._packet)); errors.Is(err, avutil.ErrAgain) {
+		outPacket := newPacket()
+		if err := averror(avcodec.ReceiveBitstreamFilterPacket(ctx.ctx, outPacket._packet)); errors.Is(err, avutil.ErrAgain) || errors.Is(err, io.EOF) {
+			releasePacket(outPacket)
 			break
 		} else if err != nil {
-			// TODO: consider freeing previously received packets here
-			return nil, err
+			releasePacket(outPacket)
+			for _, p := range outPackets[n:] {
+				releasePacket(p)
+			}
+
+			return outPackets[:n], err
 		}
 
 		outPackets = append(outPackets, outPacket)
@@ -0,0 +1,134 @@
+package av
+
+import (
+	"reflect"
+	"time"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avformat"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// Chapter is a chapter marker of a container. Start and End are in TimeBase
+// units.
+type Chapter struct {
+	ID       int64
+	TimeBase avutil.Rational
+	Start    int64
+	End      int64
+	Metadata map[string]string
+}
+
+// Title returns the title tag of the chapter.
+func (c Chapter) Title() string {
+	return c.Metadata["title"]
+}
+
+func (c Chapter) StartTime() time.Duration {
+	return timestampToDuration(c.Start, c.TimeBase)
+}
+
+func (c Chapter) EndTime() time.Duration {
+	return timestampToDuration(c.End, c.TimeBase)
+}
+
+// Rescale returns a copy of the chapter with the timestamps converted to the
+// given time base.
+func (c Chapter) Rescale(timeBase avutil.Rational) Chapter {
+	c.Start = avutil.RescaleQ(c.Start, c.TimeBase, timeBase)
+	c.End = avutil.RescaleQ(c.End, c.TimeBase, timeBase)
+	c.TimeBase = timeBase
+
+	return c
+}
+
+func (ctx *formatContext) chapters() []*avformat.Chapter {
+	return *(*[]*avformat.Chapter)(unsafe.Pointer(&reflect.SliceHeader{Data: uintptr(unsafe.Pointer(ctx._formatContext.Chapters)), Len: int(ctx.NbChapters), Cap: int(ctx.NbChapters)}))
+}
+
+// Chapters returns the chapters of the container.
+func (ctx *formatContext) Chapters() []Chapter {
+	chapters := ctx.chapters()
+	ret := make([]Chapter, len(chapters))
+	for i, chapter := range chapters {
+		ret[i] = Chapter{
+			ID:       int64(chapter.ID),
+			TimeBase: chapter.TimeBase,
+			Start:    chapter.Start,
+			End:      chapter.End,
+			Metadata: dictToMap(chapter.Metadata),
+		}
+	}
+
+	return ret
+}
+
+// AddChapter adds a chapter to the output. It must be called before the
+// header is written.
+func (ctx *OutputFormatContext) AddChapter(chapter Chapter) error {
+	if chapter.TimeBase.IsZero() {
+		return errors.New("chapter time base must not be zero")
+	}
+
+	c := (*avformat.Chapter)(avutil.MallocZeroed(uint64(unsafe.Sizeof(avformat.Chapter{}))))
+	if c == nil {
+		panic(avutil.ErrNoMem)
+	}
+
+	c.ID = int32(chapter.ID)
+	c.TimeBase = chapter.TimeBase
+	c.Start = chapter.Start
+	c.End = chapter.End
+
+	if err := replaceDict(&c.Metadata, chapter.Metadata); err != nil {
+		avutil.FreeDict(&c.Metadata)
+		avutil.Free(unsafe.Pointer(c))
+		return err
+	}
+
+	// the chapters are freed along with the context
+	if err := averror(avutil.AddDynarray(unsafe.Pointer(&ctx._formatContext.Chapters), (*int32)(unsafe.Pointer(&ctx._formatContext.NbChapters)), unsafe.Pointer(c))); err != nil {
+		avutil.FreeDict(&c.Metadata)
+		avutil.Free(unsafe.Pointer(c))
+		return err
+	}
+
+	return nil
+}
+
+// CopyChapters adds the chapters of input between start and end to the
+// output, shifted so that start becomes zero. Chapters are only limited by
+// end if it is not zero.
+func (ctx *OutputFormatContext) CopyChapters(input *InputFormatContext, start, end time.Duration) error {
+	for _, chapter := range input.Chapters() {
+		offset := durationToTimestamp(start, chapter.TimeBase)
+		chapter.Start -= offset
+		chapter.End -= offset
+
+		if end > 0 {
+			limit := durationToTimestamp(end, chapter.TimeBase) - offset
+			if chapter.Start >= limit {
+				continue
+			}
+
+			if chapter.End > limit {
+				chapter.End = limit
+			}
+		}
+
+		if chapter.End <= 0 {
+			continue
+		}
+
+		if chapter.Start < 0 {
+			chapter.Start = 0
+		}
+
+		if err := ctx.AddChapter(chapter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package av_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+type chapterSpan struct {
+	title      string
+	start, end time.Duration
+}
+
+func assertChapters(t *testing.T, input *av.InputFormatContext, want ...chapterSpan) {
+	t.Helper()
+
+	chapters := input.Chapters()
+	if len(chapters) != len(want) {
+		t.Fatalf("got %d chapters, want %d", len(chapters), len(want))
+	}
+
+	for i, chapter := range chapters {
+		got := chapterSpan{chapter.Title(), chapter.StartTime(), chapter.EndTime()}
+		if got != want[i] {
+			t.Errorf("got chapter %d %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+// chapteredClip returns a clip with an intro chapter for the first second
+// and a main chapter for the second second.
+func chapteredClip(t *testing.T) *av.InputFormatContext {
+	t.Helper()
+
+	return muxClip(t, func(output *av.OutputFormatContext, stream *av.Stream) {
+		for i, title := range []string{"Intro", "Main"} {
+			err := output.AddChapter(av.Chapter{
+				ID:       int64(i + 1),
+				TimeBase: avutil.Rat(1, 1000),
+				Start:    int64(i * 1000),
+				End:      int64(i*1000 + 1000),
+				Metadata: map[string]string{"title": title},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestAddChapter(t *testing.T) {
+	assertChapters(t, chapteredClip(t),
+		chapterSpan{"Intro", 0, time.Second},
+		chapterSpan{"Main", time.Second, 2 * time.Second},
+	)
+
+	output, err := av.NewOutputContext("null")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer output.Free()
+
+	if err := output.AddChapter(av.Chapter{End: 1}); err == nil {
+		t.Error("expected a chapter without time base to be rejected")
+	}
+}
+
+func TestRemuxChapters(t *testing.T) {
+	var buf bytes.Buffer
+	output, err := av.NewWriterOutputContext("matroska", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer output.Free()
+
+	// the chapters are cut to the trimmed range and shifted to start at zero
+	err = av.Remux(context.Background(), chapteredClip(t), output, av.RemuxOptions{
+		Start: 500 * time.Millisecond,
+		End:   1500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	assertChapters(t, openClip(t, buf.Bytes()),
+		chapterSpan{"Intro", 0, 500 * time.Millisecond},
+		chapterSpan{"Main", 500 * time.Millisecond, time.Second},
+	)
+}
+
+func TestPipelineCopyChapters(t *testing.T) {
+	for _, copyChapters := range []bool{false, true} {
+		var buf bytes.Buffer
+		output, err := av.NewWriterOutputContext("matroska", &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer output.Free()
+
+		p := av.Pipeline{
+			Input:        chapteredClip(t),
+			Output:       output,
+			CopyChapters: copyChapters,
+		}
+
+		if err := p.Run(context.Background()); err != nil {
+			t.Fatal(fmterrors.FormatString(err))
+		}
+
+		result := openClip(t, buf.Bytes())
+		if !copyChapters {
+			assertChapters(t, result)
+			continue
+		}
+
+		assertChapters(t, result,
+			chapterSpan{"Intro", 0, time.Second},
+			chapterSpan{"Main", time.Second, 2 * time.Second},
+		)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"unsafe"
 
+	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avcodec"
 	"github.com/ssttevee/go-av/avutil"
 )
@@ -77,6 +78,45 @@ func (c *Codec) Name() string {
 	return c._codec.Name.String()
 }
 
+func (c *Codec) LongName() string {
+	return c._codec.LongName.String()
+}
+
+func (c *Codec) IsEncoder() bool {
+	return avcodec.IsEncoder(c._codec) != 0
+}
+
+func (c *Codec) IsDecoder() bool {
+	return avcodec.IsDecoder(c._codec) != 0
+}
+
+// CodecIterator iterates over the encoders and decoders in libavcodec. The
+// zero value is ready to use.
+type CodecIterator struct {
+	opaque unsafe.Pointer
+}
+
+// Next returns the next codec, or nil once all codecs have been returned.
+func (it *CodecIterator) Next() *Codec {
+	codec := avcodec.IterateCodecs(&it.opaque)
+	if codec == nil {
+		return nil
+	}
+
+	return &Codec{_codec: codec}
+}
+
+// Codecs returns all encoders and decoders in libavcodec.
+func Codecs() []*Codec {
+	var codecs []*Codec
+	var it CodecIterator
+	for codec := it.Next(); codec != nil; codec = it.Next() {
+		codecs = append(codecs, codec)
+	}
+
+	return codecs
+}
+
 func countPixelFormats(fmts *avutil.PixelFormat) int {
 	if fmts == nil {
 		return 0
@@ -166,6 +206,51 @@ func (c *Codec) SampleFmt(i int) avutil.SampleFormat {
 	return *(*avutil.SampleFormat)(unsafe.Pointer(uintptr(unsafe.Pointer(c._codec.SampleFmts)) + unsafe.Sizeof(*c._codec.SampleFmts)*uintptr(i)))
 }
 
+// SupportedSampleRates returns the sample rates supported by the codec, or nil
+// if any sample rate is supported.
+func (c *Codec) SupportedSampleRates() []int32 {
+	if c._codec.SupportedSamplerates == nil {
+		return nil
+	}
+
+	var rates []int32
+	for ptr := uintptr(unsafe.Pointer(c._codec.SupportedSamplerates)); *(*int32)(unsafe.Pointer(ptr)) != 0; ptr += unsafe.Sizeof(int32(0)) {
+		rates = append(rates, *(*int32)(unsafe.Pointer(ptr)))
+	}
+
+	return rates
+}
+
+// SupportedFrameRates returns the frame rates supported by the codec, or nil
+// if any frame rate is supported.
+func (c *Codec) SupportedFrameRates() []avutil.Rational {
+	if c._codec.SupportedFramerates == nil {
+		return nil
+	}
+
+	var rates []avutil.Rational
+	for ptr := uintptr(unsafe.Pointer(c._codec.SupportedFramerates)); !(*avutil.Rational)(unsafe.Pointer(ptr)).IsZero(); ptr += unsafe.Sizeof(avutil.Rational{}) {
+		rates = append(rates, *(*avutil.Rational)(unsafe.Pointer(ptr)))
+	}
+
+	return rates
+}
+
+// ChannelLayouts returns the channel layouts supported by the codec, or nil if
+// any channel layout is supported.
+func (c *Codec) ChannelLayouts() []uint64 {
+	if c._codec.ChannelLayouts == nil {
+		return nil
+	}
+
+	var layouts []uint64
+	for ptr := uintptr(unsafe.Pointer(c._codec.ChannelLayouts)); *(*uint64)(unsafe.Pointer(ptr)) != 0; ptr += unsafe.Sizeof(uint64(0)) {
+		layouts = append(layouts, *(*uint64)(unsafe.Pointer(ptr)))
+	}
+
+	return layouts
+}
+
 func (c *Codec) GetProfileName(profile int32) string {
 	return avcodec.GetProfileName(c._codec, profile).String()
 }
@@ -201,6 +286,9 @@ type codecContext struct {
 
 	pinnedDataOnce sync.Once
 
+	// options are passed to the codec when it is opened
+	options []Option
+
 	initOnce sync.Once
 	initErr  error
 }
@@ -211,8 +299,12 @@ func newCodecContext(codec *Codec, params *CodecParameters) (*avcodec.Context, e
 		panic(avutil.ErrNoMem)
 	}
 
+	trackPointer(CodecContextObject, unsafe.Pointer(ctx))
+
 	if params != nil {
 		if err := averror(avcodec.ParametersToContext(ctx, params._codecParameters)); err != nil {
+			untrackPointer(CodecContextObject, unsafe.Pointer(ctx))
+			avcodec.FreeContext(&ctx)
 			return nil, err
 		}
 	}
@@ -222,31 +314,63 @@ func newCodecContext(codec *Codec, params *CodecParameters) (*avcodec.Context, e
 
 func (ctx *codecContext) pinnedData() *pinnedCodecContextData {
 	ctx.pinnedDataOnce.Do(func() {
-		ctx.Opaque = unsafe.Pointer(cgo.NewHandle(&pinnedCodecContextData{}))
+		ctx.Opaque = unsafe.Pointer(newHandle(&pinnedCodecContextData{}))
 	})
 
 	return unwrapPinnedCodecContextData(ctx.Opaque)
 }
 
+func (ctx *codecContext) free() {
+	if ctx.freed() {
+		return
+	}
+
+	ctx.finalizedPinnedData()
+	untrackPointer(CodecContextObject, unsafe.Pointer(ctx._codecContext))
+	// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+	codecContext := (*avcodec.Context)(ctx._codecContext)
+	avcodec.FreeContext(&codecContext)
+	ctx._codecContext = codecContext
+}
+
+func (ctx *codecContext) freed() bool {
+	return ctx._codecContext == nil
+}
+
 func (ctx *codecContext) finalizedPinnedData() {
 	if ctx.Opaque == nil {
 		return
 	}
 
-	cgo.Handle(ctx.Opaque).Delete()
+	setContextLogHandler(uintptr(ctx.Opaque), nil)
+	deleteHandle(cgo.Handle(ctx.Opaque))
 }
 
 func (ctx *codecContext) Codec() *Codec {
+	if ctx.freed() {
+		return nil
+	}
+
 	return &Codec{
 		_codec: ctx._codecContext.Codec,
 	}
 }
 
+// CodecID returns the id of the codec, or AV_CODEC_ID_NONE if the context was
+// freed.
 func (ctx *codecContext) CodecID() avcodec.ID {
+	if ctx.freed() {
+		return avcodec.ID(0)
+	}
+
 	return avcodec.ID(ctx._codecContext.CodecID)
 }
 
 func (ctx *codecContext) CodecParameters() *CodecParameters {
+	if ctx.freed() {
+		return nil
+	}
+
 	var parameters avcodec.Parameters
 	if err := averror(avcodec.ParametersFromContext(&parameters, ctx._codecContext)); err != nil {
 		panic(err)
@@ -258,6 +382,10 @@ func (ctx *codecContext) CodecParameters() *CodecParameters {
 }
 
 func (ctx *codecContext) SetGetFormat(f func([]avutil.PixelFormat) avutil.PixelFormat) {
+	if ctx.freed() {
+		return
+	}
+
 	if f == nil {
 		// ctx.GetFormat = (*[0]byte)(C.avcodec_default_get_format)
 		ctx.GetFormat = nil
@@ -268,7 +396,7 @@ func (ctx *codecContext) SetGetFormat(f func([]avutil.PixelFormat) avutil.PixelF
 }
 
 func (ctx *codecContext) HwDeviceCtx() *HWDeviceContext {
-	if ctx._codecContext.HwDeviceCtx == nil {
+	if ctx.freed() || ctx._codecContext.HwDeviceCtx == nil {
 		return nil
 	}
 
@@ -276,6 +404,10 @@ func (ctx *codecContext) HwDeviceCtx() *HWDeviceContext {
 }
 
 func (ctx *codecContext) SetHwDeviceCtx(deviceCtx *HWDeviceContext) {
+	if ctx.freed() {
+		return
+	}
+
 	if ctx._codecContext.HwDeviceCtx != nil {
 		avutil.UnrefBuffer(&ctx._codecContext.HwDeviceCtx)
 	}
@@ -288,7 +420,7 @@ func (ctx *codecContext) SetHwDeviceCtx(deviceCtx *HWDeviceContext) {
 }
 
 func (ctx *codecContext) HwFramesCtx() *HWFramesContext {
-	if ctx._codecContext.HwFramesCtx == nil {
+	if ctx.freed() || ctx._codecContext.HwFramesCtx == nil {
 		return nil
 	}
 
@@ -296,6 +428,10 @@ func (ctx *codecContext) HwFramesCtx() *HWFramesContext {
 }
 
 func (ctx *codecContext) SetHwFramesCtx(framesCtx *HWFramesContext) {
+	if ctx.freed() {
+		return
+	}
+
 	if ctx._codecContext.HwFramesCtx != nil {
 		avutil.UnrefBuffer(&ctx._codecContext.HwFramesCtx)
 	}
@@ -307,22 +443,139 @@ func (ctx *codecContext) SetHwFramesCtx(framesCtx *HWFramesContext) {
 	}
 }
 
+// SetOption sets a codec specific or generic option of the context. Codec
+// specific options take precedence over generic options with the same name,
+// like the profile option of libx264.
 func (ctx *codecContext) SetOption(name string, value interface{}) error {
-	return setOption(ctx._codecContext.PrivData, name, value, 0)
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	if ctx._codecContext.PrivData != nil {
+		if err := setOption(ctx._codecContext.PrivData, name, value, 0); !errors.Is(err, avutil.ErrOptionNotFound) {
+			return err
+		}
+	}
+
+	return setOption(unsafe.Pointer(ctx._codecContext), name, value, 0)
 }
 
+// GetOption returns the value of a codec specific or generic option of the
+// context, looked up in the same order as SetOption.
 func (ctx *codecContext) GetOption(name string) (interface{}, error) {
-	return getOption(ctx._codecContext.PrivData, name, 0)
+	if ctx.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
+	if ctx._codecContext.PrivData != nil {
+		if v, err := getOption(ctx._codecContext.PrivData, name, 0); !errors.Is(err, avutil.ErrOptionNotFound) {
+			return v, err
+		}
+	}
+
+	return getOption(unsafe.Pointer(ctx._codecContext), name, 0)
+}
+
+// Options returns the generic options of the context followed by the codec
+// specific options.
+func (ctx *codecContext) Options() []OptionInfo {
+	if ctx.freed() {
+		return nil
+	}
+
+	return listOptions(unsafe.Pointer(ctx._codecContext))
+}
+
+// FlushBuffers resets the internal state of the codec and discards any
+// buffered frames or packets. It should be called after seeking so that data
+// from before the seek is not mixed with data from after it.
+func (ctx *codecContext) FlushBuffers() {
+	if ctx.freed() {
+		return
+	}
+
+	avcodec.FlushBuffers(ctx._codecContext)
 }
 
 func (ctx *codecContext) init() error {
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	// unconsumed options do not prevent the codec from being used, so they are
+	// only reported by the call that opens it
+	var optionsErr error
 	ctx.initOnce.Do(func() {
-		ctx.initErr = averror(avcodec.Open(ctx._codecContext, nil, nil))
+		dict, err := resolveOptionsDict(ctx.options...)
+		if err != nil {
+			ctx.initErr = err
+			return
+		}
+
+		defer avutil.FreeDict(&dict)
+
+		logger, err := popLogger(&dict)
+		if err != nil {
+			ctx.initErr = err
+			return
+		}
+
+		if logger != nil {
+			ctx.SetLogger(logger)
+		}
+
+		// the messages that explain a failure are attached to the returned
+		// error
+		ctx.pinnedData()
+		capture := captureLogs(uintptr(ctx.Opaque))
+		if ctx.initErr = capture.stop(averror(avcodec.Open(ctx._codecContext, nil, &dict))); ctx.initErr != nil {
+			return
+		}
+
+		optionsErr = unconsumedOptionsError(dict)
 	})
 
-	return ctx.initErr
+	if ctx.initErr != nil {
+		return ctx.initErr
+	}
+
+	return optionsErr
 }
 
-func (ctx *codecContext) Open() error {
+// Open opens the codec with the options given to the constructor followed by
+// opts. The codec is opened implicitly without additional options when it is
+// first used, after which Open has no effect.
+func (ctx *codecContext) Open(opts ...Option) error {
+	ctx.options = append(ctx.options, opts...)
 	return ctx.init()
 }
+
+func (ctx *codecContext) AudioFormat() AudioFormat {
+	if ctx.freed() {
+		return AudioFormat{}
+	}
+
+	timeBase := ctx._codecContext.TimeBase
+	if !ctx._codecContext.PktTimebase.IsZero() {
+		timeBase = ctx._codecContext.PktTimebase
+	}
+
+	return AudioFormat{
+		SampleFormat:  ctx._codecContext.SampleFmt,
+		SampleRate:    ctx._codecContext.SampleRate,
+		ChannelLayout: channelLayoutOrDefault(ctx._codecContext.ChannelLayout, ctx._codecContext.Channels),
+		TimeBase:      timeBase,
+	}
+}
+
+func (ctx *codecContext) VideoFormat() VideoFormat {
+	if ctx.freed() {
+		return VideoFormat{}
+	}
+
+	return VideoFormat{
+		Width:       ctx._codecContext.Width,
+		Height:      ctx._codecContext.Height,
+		PixelFormat: ctx._codecContext.PixFmt,
+	}
+}
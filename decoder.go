@@ -2,7 +2,10 @@ package av
 
 import (
 	"fmt"
+	"io"
+	"math"
 	"runtime"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avcodec"
@@ -13,7 +16,7 @@ type DecoderContext struct {
 	codecContext
 }
 
-func NewDecoderContext(codec *Codec, params *CodecParameters) (*DecoderContext, error) {
+func NewDecoderContext(codec *Codec, params *CodecParameters, opts ...Option) (*DecoderContext, error) {
 	ctx, err := newCodecContext(codec, params)
 	if err != nil {
 		return nil, err
@@ -22,21 +25,30 @@ func NewDecoderContext(codec *Codec, params *CodecParameters) (*DecoderContext,
 	ret := &DecoderContext{
 		codecContext: codecContext{
 			_codecContext: ctx,
+			options:       opts,
 		},
 	}
 
 	runtime.SetFinalizer(ret, func(ctx *DecoderContext) {
-		ctx.finalizedPinnedData()
-		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
-		codecContext := (*avcodec.Context)(ctx._codecContext)
-		avcodec.FreeContext(&codecContext)
-		ctx._codecContext = codecContext
+		ctx.free()
 	})
 
 	return ret, nil
 }
 
+// Free releases the context without waiting for the garbage collector. It is
+// safe to call Free more than once and using the context afterwards returns
+// ErrClosed.
+func (ctx *DecoderContext) Free() {
+	runtime.SetFinalizer(ctx, nil)
+	ctx.free()
+}
+
 func (ctx *DecoderContext) BufferSourceArgs() string {
+	if ctx.CodecType == avutil.Audio {
+		return ctx.AudioFormat().BufferSourceArgs()
+	}
+
 	var framerateArg string
 	if !ctx.Framerate.IsZero() {
 		framerateArg = ":frame_rate=" + ctx.Framerate.String()
@@ -45,11 +57,22 @@ func (ctx *DecoderContext) BufferSourceArgs() string {
 	return fmt.Sprintf("video_size=%dx%d:pix_fmt=%d:time_base=%s:pixel_aspect=%s", ctx.Width, ctx.Height, int(ctx.PixFmt), ctx.TimeBase, ctx.SampleAspectRatio) + framerateArg
 }
 
+// SendPacket sends a packet to the decoder. A nil packet signals the end of
+// the stream, after which the remaining frames can be received until io.EOF is
+// returned.
 func (ctx *DecoderContext) SendPacket(packet *Packet) error {
 	if err := ctx.init(); err != nil {
 		return err
 	}
 
+	if packet == nil {
+		return averror(avcodec.SendPacket(ctx._codecContext, nil))
+	} else if packet.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	defer runtime.KeepAlive(packet)
+
 	return averror(avcodec.SendPacket(ctx._codecContext, packet._packet))
 }
 
@@ -58,6 +81,10 @@ func (ctx *DecoderContext) ReceiveFrameReuse(frame *Frame) error {
 		return err
 	}
 
+	if frame.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	return averror(avcodec.ReceiveFrame(ctx._codecContext, frame.prepare()))
 }
 
@@ -74,12 +101,48 @@ func (ctx *DecoderContext) ReceiveFrame() (*Frame, error) {
 	return frame, nil
 }
 
+// ReceiveFrameWithPool is like ReceiveFrame, but the frame is taken from pool.
+func (ctx *DecoderContext) ReceiveFrameWithPool(pool *FramePool) (*Frame, error) {
+	frame := pool.Get()
+	if err := ctx.ReceiveFrameReuse(frame); err != nil {
+		pool.Put(frame)
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// Drain signals the end of the stream to the decoder and returns the frames
+// that it was still holding back. FlushBuffers must be called before the
+// decoder can be used again.
+func (ctx *DecoderContext) Drain() ([]*Frame, error) {
+	// io.EOF means that the decoder is already draining
+	if err := ctx.SendPacket(nil); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var frames []*Frame
+	for {
+		frame, err := ctx.ReceiveFrame()
+		if errors.Is(err, io.EOF) {
+			return frames, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		frames = append(frames, frame)
+	}
+}
+
 type FrameIterator struct {
 	ifc         *InputFormatContext
 	dc          *DecoderContext
 	streamIndex int32
 
 	pkt *Packet
+
+	// ownsDecoder is set if the decoder was created by the iterator
+	ownsDecoder bool
 }
 
 func (ctx *DecoderContext) NewFrameIterator(ifc *InputFormatContext, streamIndex int32) *FrameIterator {
@@ -92,11 +155,12 @@ func (ctx *DecoderContext) NewFrameIterator(ifc *InputFormatContext, streamIndex
 }
 
 func NewFrameIterator(ifc *InputFormatContext, streamIndex int32) (*FrameIterator, error) {
-	stream := ifc.Streams()[streamIndex]
-	if stream == nil {
+	if streamIndex < 0 || int(streamIndex) >= len(ifc.streams()) {
 		return nil, errors.Errorf("stream index %d not found in input", streamIndex)
 	}
 
+	stream := ifc.Stream(int(streamIndex))
+
 	codec, err := FindDecoderCodecByID(stream._stream.Codecpar.CodecID)
 	if err != nil {
 		return nil, err
@@ -107,11 +171,23 @@ func NewFrameIterator(ifc *InputFormatContext, streamIndex int32) (*FrameIterato
 		return nil, err
 	}
 
-	return &FrameIterator{
-		ifc: ifc,
-		dc:  dc,
-		pkt: NewPacket(),
-	}, nil
+	it := dc.NewFrameIterator(ifc, streamIndex)
+	it.ownsDecoder = true
+
+	return it, nil
+}
+
+// Close releases the packet of the iterator and the decoder if it was created
+// by NewFrameIterator. The input is not closed. It is safe to call Close more
+// than once.
+func (it *FrameIterator) Close() error {
+	it.pkt.Free()
+
+	if it.ownsDecoder {
+		it.dc.Free()
+	}
+
+	return nil
 }
 
 func (it *FrameIterator) Next(frame *Frame) error {
@@ -122,16 +198,54 @@ func (it *FrameIterator) Next(frame *Frame) error {
 			return err
 		}
 
-		for {
-			if err := it.ifc.ReadPacketReuse(it.pkt); err != nil {
-				return err
-			} else if it.pkt.StreamIndex == it.streamIndex {
-				break
-			}
+		packet, err := it.readPacket()
+		if err != nil {
+			return err
+		}
+
+		if err := it.dc.SendPacket(packet); err != nil {
+			return err
+		}
+	}
+}
+
+// readPacket returns the next packet of the stream, or nil at the end of the
+// input so that the frames held back by the decoder are drained.
+func (it *FrameIterator) readPacket() (*Packet, error) {
+	for {
+		if err := it.ifc.ReadPacketReuse(it.pkt); errors.Is(err, io.EOF) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		} else if it.pkt.StreamIndex == it.streamIndex {
+			return it.pkt, nil
 		}
+	}
+}
 
-		if err := it.dc.SendPacket(it.pkt); err != nil {
+// SeekTo seeks the input to the given position in the timeline of the stream
+// and decodes forward until the first frame at or after it, which is stored
+// in frame. The decoder is flushed so that no frames from before the seek are
+// returned. Packets of other streams that are read in the meantime are
+// discarded.
+func (it *FrameIterator) SeekTo(d time.Duration, frame *Frame) error {
+	target := streamTimestamp(it.ifc.Stream(int(it.streamIndex)), d)
+
+	if err := it.ifc.SeekFile(it.streamIndex, math.MinInt64, target, target, 0); err != nil {
+		return err
+	}
+
+	it.dc.FlushBuffers()
+
+	for {
+		if err := it.Next(frame); err != nil {
 			return err
 		}
+
+		// frame timestamps are in the stream time base, but frames without
+		// one can not be placed, so assume that they are at the target
+		if pts := frame.BestEffortTimestamp; pts == avutil.NoPtsValue || pts >= target {
+			return nil
+		}
 	}
 }
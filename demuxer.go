@@ -0,0 +1,196 @@
+package av
+
+import (
+	"context"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// PacketHandler consumes the packets of a stream. A nil packet is passed once
+// the demuxer stops, either at the end of the input or because of an error.
+type PacketHandler interface {
+	HandlePacket(packet *Packet) error
+}
+
+// PacketHandlerFunc adapts a function to a PacketHandler.
+type PacketHandlerFunc func(packet *Packet) error
+
+func (f PacketHandlerFunc) HandlePacket(packet *Packet) error {
+	return f(packet)
+}
+
+// Demuxer reads the packets of an input once and dispatches them to the
+// handlers of their streams. Packets of streams without a handler are
+// discarded.
+type Demuxer struct {
+	input    *InputFormatContext
+	handlers map[int32]PacketHandler
+
+	// ctx is the context of the running Run call
+	ctx context.Context
+}
+
+func NewDemuxer(input *InputFormatContext) *Demuxer {
+	return &Demuxer{
+		input:    input,
+		handlers: map[int32]PacketHandler{},
+	}
+}
+
+// Handle registers the handler for the packets of the given stream, replacing
+// any previous handler. The packet passed to the handler is reused for the next
+// read, so it must be cloned if it is retained.
+func (d *Demuxer) Handle(streamIndex int32, h PacketHandler) error {
+	if streamIndex < 0 || int(streamIndex) >= len(d.input.streams()) {
+		return errors.Errorf("stream index %d not found in input", streamIndex)
+	}
+
+	d.handlers[streamIndex] = h
+
+	return nil
+}
+
+// HandleFunc registers a function as the handler for the packets of the given
+// stream.
+func (d *Demuxer) HandleFunc(streamIndex int32, f func(packet *Packet) error) error {
+	return d.Handle(streamIndex, PacketHandlerFunc(f))
+}
+
+// HandleChannel sends a clone of each packet of the given stream to ch, which
+// is closed once the demuxer stops. Sends block until ch is received from or
+// the context of Run is done. Packets read by later calls to Run are
+// discarded, since ch is already closed.
+func (d *Demuxer) HandleChannel(streamIndex int32, ch chan<- *Packet) error {
+	var closed bool
+	return d.HandleFunc(streamIndex, func(packet *Packet) error {
+		if closed {
+			return nil
+		}
+
+		if packet == nil {
+			closed = true
+			close(ch)
+			return nil
+		}
+
+		clone, err := packet.Clone()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case ch <- clone:
+			return nil
+
+		case <-d.ctx.Done():
+			clone.Free()
+			return d.ctx.Err()
+		}
+	})
+}
+
+// HandleDecoder decodes the packets of the given stream with dc and passes
+// each decoded frame to f. The frame is reused for the next frame, so it must
+// be cloned if it is retained. The decoder is drained once the demuxer
+// stops.
+func (d *Demuxer) HandleDecoder(streamIndex int32, dc *DecoderContext, f func(frame *Frame) error) error {
+	var frame *Frame
+
+	return d.HandleFunc(streamIndex, func(packet *Packet) error {
+		if frame == nil {
+			frame = framePool.Get()
+		}
+
+		if packet == nil {
+			// the frame is released once the decoder is drained, and taken
+			// again if the demuxer is run another time
+			defer func() {
+				framePool.Put(frame)
+				frame = nil
+			}()
+		}
+
+		if err := dc.SendPacket(packet); packet == nil && errors.Is(err, io.EOF) {
+			// the decoder was already drained by a previous run
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		for {
+			if err := dc.ReceiveFrameReuse(frame); errors.Is(err, avutil.ErrAgain) || errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			if err := f(frame); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// Run reads the input until the end and dispatches its packets. The handlers
+// are called from the calling goroutine. Reading is aborted when ctx is done.
+// When Run returns, every handler is passed a nil packet in the order of the
+// stream indexes, so that channels are closed even if demuxing failed.
+func (d *Demuxer) Run(ctx context.Context) error {
+	d.ctx = ctx
+	defer func() {
+		d.ctx = nil
+	}()
+
+	err := d.run(ctx)
+
+	for _, i := range d.streamIndexes() {
+		// the handlers are always notified, but only the first error counts
+		if herr := d.handlers[i].HandlePacket(nil); herr != nil && err == nil {
+			err = errors.WithMessagef(herr, "stream %d", i)
+		}
+	}
+
+	return err
+}
+
+func (d *Demuxer) run(ctx context.Context) error {
+	packet := packetPool.Get()
+	defer packetPool.Put(packet)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := d.input.ReadPacketReuseContext(ctx, packet); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		h, ok := d.handlers[packet.StreamIndex]
+		if !ok {
+			continue
+		}
+
+		if err := h.HandlePacket(packet); err != nil {
+			return errors.WithMessagef(err, "stream %d", packet.StreamIndex)
+		}
+	}
+}
+
+func (d *Demuxer) streamIndexes() []int32 {
+	indexes := make([]int32, 0, len(d.handlers))
+	for i := range d.handlers {
+		indexes = append(indexes, i)
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i] < indexes[j]
+	})
+
+	return indexes
+}
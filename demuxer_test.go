@@ -0,0 +1,108 @@
+package av_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+func TestDemuxer(t *testing.T) {
+	input := openClip(t, testClip(t, time.Second))
+
+	videoIndex, codec, err := input.FindBestStream(avutil.Video)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	audioIndex, _, err := input.FindBestStream(avutil.Audio)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder, err := av.NewDecoderContext(codec, input.Stream(videoIndex).Codecpar())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer decoder.Free()
+
+	d := av.NewDemuxer(input)
+
+	var frames int
+	if err := d.HandleDecoder(int32(videoIndex), decoder, func(frame *av.Frame) error {
+		frames++
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ch := make(chan *av.Packet)
+	if err := d.HandleChannel(int32(audioIndex), ch); err != nil {
+		t.Fatal(err)
+	}
+
+	packets := make(chan int)
+	go func() {
+		var n int
+		for packet := range ch {
+			if int(packet.StreamIndex) != audioIndex {
+				t.Errorf("got packet of stream %d on the audio channel", packet.StreamIndex)
+			}
+
+			packet.Free()
+			n++
+		}
+
+		packets <- n
+	}()
+
+	if err := d.Run(context.Background()); err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	if n := <-packets; n == 0 {
+		t.Error("got no audio packets")
+	}
+
+	// one second of 24 fps video
+	if frames < 20 || frames > 28 {
+		t.Errorf("got %d frames, want about 24", frames)
+	}
+
+	// the input is at its end, but the handlers are notified again, which
+	// must neither close the channel twice nor fail to drain the decoder
+	if err := d.Run(context.Background()); err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+}
+
+func TestDemuxerCanceled(t *testing.T) {
+	input := openClip(t, testClip(t, time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// nothing receives from the channel, so the first send blocks until the
+	// context is canceled
+	ch := make(chan *av.Packet)
+
+	d := av.NewDemuxer(input)
+	if err := d.HandleChannel(0, ch); err != nil {
+		t.Fatal(err)
+	}
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if err := d.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+
+	if _, ok := <-ch; ok {
+		t.Error("channel was not closed")
+	}
+}
@@ -1,6 +1,7 @@
 package av
 
 import (
+	"io"
 	"runtime"
 
 	"github.com/pkg/errors"
@@ -12,7 +13,7 @@ type EncoderContext struct {
 	codecContext
 }
 
-func NewEncoderContext(codec *Codec, params *CodecParameters) (*EncoderContext, error) {
+func NewEncoderContext(codec *Codec, params *CodecParameters, opts ...Option) (*EncoderContext, error) {
 	ctx, err := newCodecContext(codec, params)
 	if err != nil {
 		return nil, err
@@ -21,35 +22,57 @@ func NewEncoderContext(codec *Codec, params *CodecParameters) (*EncoderContext,
 	ret := &EncoderContext{
 		codecContext: codecContext{
 			_codecContext: ctx,
+			options:       opts,
 		},
 	}
 
 	runtime.SetFinalizer(ret, func(ctx *EncoderContext) {
-		ctx.finalizedPinnedData()
-		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
-		codecContext := (*avcodec.Context)(ctx._codecContext)
-		avcodec.FreeContext(&codecContext)
-		ctx._codecContext = codecContext
+		ctx.free()
 	})
 
 	return ret, nil
 }
 
+// Free releases the context without waiting for the garbage collector. It is
+// safe to call Free more than once and using the context afterwards returns
+// ErrClosed.
+func (ctx *EncoderContext) Free() {
+	runtime.SetFinalizer(ctx, nil)
+	ctx.free()
+}
+
+// SendFrame sends a frame to the encoder. A nil frame signals the end of the
+// stream, after which the remaining packets can be received until io.EOF is
+// returned.
 func (ctx *EncoderContext) SendFrame(frame *Frame) error {
 	if err := ctx.init(); err != nil {
 		return err
 	}
 
+	if frame == nil {
+		return averror(avcodec.SendFrame(ctx._codecContext, nil))
+	} else if frame.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	defer runtime.KeepAlive(frame)
 
 	return averror(avcodec.SendFrame(ctx._codecContext, frame._frame))
 }
 
 func (ctx *EncoderContext) ReceivePacketReuse(packet *Packet) error {
+	if ctx.freed() || packet.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	return averror(avcodec.ReceivePacket(ctx._codecContext, packet.prepare()))
 }
 
 func (ctx *EncoderContext) ReceivePacket() (*Packet, error) {
+	if ctx.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
 	packet := NewPacket()
 	if err := averror(avcodec.ReceivePacket(ctx._codecContext, packet._packet)); err != nil {
 		return nil, err
@@ -58,8 +81,26 @@ func (ctx *EncoderContext) ReceivePacket() (*Packet, error) {
 	return packet, nil
 }
 
+// ReceivePacketWithPool is like ReceivePacket, but the packet is taken from
+// pool.
+func (ctx *EncoderContext) ReceivePacketWithPool(pool *PacketPool) (*Packet, error) {
+	packet := pool.Get()
+	if err := ctx.ReceivePacketReuse(packet); err != nil {
+		pool.Put(packet)
+		return nil, err
+	}
+
+	return packet, nil
+}
+
+// FramePackets sends a frame to the encoder and returns the packets that are
+// ready. If frame is nil, the encoder is drained instead.
 func (ctx *EncoderContext) FramePackets(frame *Frame) ([]*Packet, error) {
-	if ctx._codecContext.HwFramesCtx != nil && frame._frame.HwFramesCtx == nil {
+	if ctx.freed() || frame != nil && frame.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
+	if frame != nil && ctx._codecContext.HwFramesCtx != nil && frame._frame.HwFramesCtx == nil {
 		hwFrame := NewFrame()
 		defer hwFrame.Unref()
 
@@ -76,7 +117,7 @@ func (ctx *EncoderContext) FramePackets(frame *Frame) ([]*Packet, error) {
 		}
 
 		frame = hwFrame
-	} else if ctx._codecContext.HwFramesCtx == nil && frame._frame.HwFramesCtx != nil {
+	} else if frame != nil && ctx._codecContext.HwFramesCtx == nil && frame._frame.HwFramesCtx != nil {
 		swFrame := NewFrame()
 		defer swFrame.Unref()
 
@@ -98,8 +139,9 @@ func (ctx *EncoderContext) FramePackets(frame *Frame) ([]*Packet, error) {
 	var packets []*Packet
 	for {
 		packet, err := ctx.ReceivePacket()
-		if errors.Is(err, avutil.ErrAgain) {
-			// ErrAgain means there are no more packets to receive
+		if errors.Is(err, avutil.ErrAgain) || errors.Is(err, io.EOF) {
+			// ErrAgain means there are no more packets to receive until the
+			// next frame is sent and io.EOF means the encoder is fully drained
 			break
 		} else if err != nil {
 			return nil, errors.WithStack(err)
@@ -110,3 +152,25 @@ func (ctx *EncoderContext) FramePackets(frame *Frame) ([]*Packet, error) {
 
 	return packets, nil
 }
+
+// Drain signals the end of the stream to the encoder and returns the packets
+// that it was still holding back. FlushBuffers must be called before the
+// encoder can be used again, if the encoder supports it.
+func (ctx *EncoderContext) Drain() ([]*Packet, error) {
+	// io.EOF means that the encoder is already draining
+	if err := ctx.SendFrame(nil); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var packets []*Packet
+	for {
+		packet, err := ctx.ReceivePacket()
+		if errors.Is(err, io.EOF) {
+			return packets, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		packets = append(packets, packet)
+	}
+}
@@ -0,0 +1,356 @@
+package av
+
+import (
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avcodec"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// VideoEncoderConfig describes the settings of a video encoder. Zero values
+// are left to the codec defaults.
+type VideoEncoderConfig struct {
+	// Codec is the name of the encoder, like "libx264".
+	Codec string `json:"codec"`
+
+	Width  int32 `json:"width"`
+	Height int32 `json:"height"`
+
+	// PixelFormat is the pixel format of the frames. The first pixel format
+	// supported by the codec is used if it is nil or none.
+	PixelFormat *avutil.PixelFormat `json:"pixel_format,omitempty"`
+
+	FrameRate avutil.Rational `json:"frame_rate"`
+
+	// TimeBase is the time base of frame timestamps. If it is zero,
+	// 1/FrameRate is assumed.
+	TimeBase          avutil.Rational `json:"time_base,omitempty"`
+	SampleAspectRatio avutil.Rational `json:"sample_aspect_ratio,omitempty"`
+
+	BitRate    int64 `json:"bit_rate,omitempty"`
+	MaxBitRate int64 `json:"max_bit_rate,omitempty"`
+	BufferSize int32 `json:"buffer_size,omitempty"`
+	GOPSize    int32 `json:"gop_size,omitempty"`
+
+	// MaxBFrames is the maximum number of consecutive b-frames. The codec
+	// default is used if it is nil.
+	MaxBFrames *int32 `json:"max_b_frames,omitempty"`
+
+	Threads      int32 `json:"threads,omitempty"`
+	GlobalHeader bool  `json:"global_header,omitempty"`
+
+	// Options are generic or codec specific options that are set on the
+	// context, like "preset" or "crf" for libx264.
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// pixelFormat returns the pixel format of the config, or the first format
+// supported by the codec if it is not set.
+func (c *VideoEncoderConfig) pixelFormat(codec *Codec) avutil.PixelFormat {
+	if c.PixelFormat != nil && *c.PixelFormat != avutil.PixelFormatNone {
+		return *c.PixelFormat
+	}
+
+	if formats := codec.PixFmts(); len(formats) > 0 {
+		return formats[0]
+	}
+
+	return avutil.PixelFormatNone
+}
+
+func (c *VideoEncoderConfig) timeBase() avutil.Rational {
+	if c.TimeBase.IsZero() {
+		return c.FrameRate.Inverse()
+	}
+
+	return c.TimeBase
+}
+
+// Validate checks that the config is complete and that the codec supports the
+// chosen pixel format and frame rate.
+func (c *VideoEncoderConfig) Validate(codec *Codec) error {
+	if err := validateEncoder(codec, avutil.Video); err != nil {
+		return err
+	}
+
+	if c.Width <= 0 || c.Height <= 0 {
+		return errors.Errorf("invalid video size: %dx%d", c.Width, c.Height)
+	}
+
+	if c.FrameRate.Num <= 0 || c.FrameRate.Den <= 0 {
+		return errors.Errorf("invalid frame rate: %s", c.FrameRate)
+	}
+
+	if c.TimeBase.Num < 0 || c.TimeBase.Num > 0 && c.TimeBase.Den <= 0 {
+		return errors.Errorf("invalid time base: %s", c.TimeBase)
+	}
+
+	pixelFormat := c.pixelFormat(codec)
+	if pixelFormat == avutil.PixelFormatNone {
+		return errors.Errorf("pixel format must be set for %s", codec.Name())
+	}
+
+	if formats := codec.PixFmts(); len(formats) > 0 && !containsPixelFormat(formats, pixelFormat) {
+		return errors.Errorf("pixel format %s is not supported by %s", pixelFormat, codec.Name())
+	}
+
+	if rates := codec.SupportedFrameRates(); len(rates) > 0 && !containsRational(rates, c.FrameRate) {
+		return errors.Errorf("frame rate %s is not supported by %s", c.FrameRate, codec.Name())
+	}
+
+	return nil
+}
+
+// ApplyTo validates the config against the codec of the context and then sets
+// the fields and options of the context. It must be called before the context
+// is opened.
+func (c *VideoEncoderConfig) ApplyTo(ctx *EncoderContext) error {
+	if err := c.Validate(ctx.Codec()); err != nil {
+		return err
+	}
+
+	ctx.Width = c.Width
+	ctx.Height = c.Height
+	ctx.PixFmt = c.pixelFormat(ctx.Codec())
+	ctx.Framerate = c.FrameRate
+	ctx.TimeBase = c.timeBase()
+
+	if !c.SampleAspectRatio.IsZero() {
+		ctx.SampleAspectRatio = c.SampleAspectRatio
+	}
+
+	if c.BitRate > 0 {
+		ctx.BitRate = c.BitRate
+	}
+
+	if c.MaxBitRate > 0 {
+		ctx.RcMaxRate = c.MaxBitRate
+	}
+
+	if c.BufferSize > 0 {
+		ctx.RcBufferSize = c.BufferSize
+	}
+
+	if c.GOPSize > 0 {
+		ctx.GopSize = c.GOPSize
+	}
+
+	if c.MaxBFrames != nil {
+		ctx.MaxBFrames = *c.MaxBFrames
+	}
+
+	return applyEncoderConfig(ctx, c.Threads, c.GlobalHeader, c.Options)
+}
+
+// NewEncoderContext finds the encoder of the config and returns a new context
+// with the config applied.
+func (c *VideoEncoderConfig) NewEncoderContext() (*EncoderContext, error) {
+	return newEncoderContextFromConfig(c.Codec, c.ApplyTo)
+}
+
+// AudioEncoderConfig describes the settings of an audio encoder. Zero values
+// are left to the codec defaults.
+type AudioEncoderConfig struct {
+	// Codec is the name of the encoder, like "aac".
+	Codec string `json:"codec"`
+
+	// SampleFormat is the sample format of the frames. The first sample format
+	// supported by the codec is used if it is nil or none.
+	SampleFormat *avutil.SampleFormat `json:"sample_format,omitempty"`
+	SampleRate   int32                `json:"sample_rate"`
+
+	// ChannelLayout is the channel layout bitmask. If it is zero, the default
+	// layout for Channels is assumed.
+	ChannelLayout uint64 `json:"channel_layout,omitempty"`
+	Channels      int32  `json:"channels,omitempty"`
+
+	BitRate      int64 `json:"bit_rate,omitempty"`
+	Threads      int32 `json:"threads,omitempty"`
+	GlobalHeader bool  `json:"global_header,omitempty"`
+
+	// Options are generic or codec specific options that are set on the
+	// context.
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// sampleFormat returns the sample format of the config, or the first format
+// supported by the codec if it is not set.
+func (c *AudioEncoderConfig) sampleFormat(codec *Codec) avutil.SampleFormat {
+	if c.SampleFormat != nil && *c.SampleFormat != avutil.SampleFormatNone {
+		return *c.SampleFormat
+	}
+
+	if formats := codec.SampleFmts(); len(formats) > 0 {
+		return formats[0]
+	}
+
+	return avutil.SampleFormatNone
+}
+
+func (c *AudioEncoderConfig) channelLayout() uint64 {
+	return channelLayoutOrDefault(c.ChannelLayout, c.Channels)
+}
+
+// Validate checks that the config is complete and that the codec supports the
+// chosen sample format, sample rate and channel layout.
+func (c *AudioEncoderConfig) Validate(codec *Codec) error {
+	if err := validateEncoder(codec, avutil.Audio); err != nil {
+		return err
+	}
+
+	if c.SampleRate <= 0 {
+		return errors.Errorf("invalid sample rate: %d", c.SampleRate)
+	}
+
+	if c.ChannelLayout == 0 && c.Channels <= 0 {
+		return errors.New("either channel layout or channels must be set")
+	}
+
+	if c.ChannelLayout != 0 && c.Channels > 0 && avutil.GetChannelLayoutNbChannels(c.ChannelLayout) != c.Channels {
+		return errors.Errorf("channel layout 0x%x does not have %d channels", c.ChannelLayout, c.Channels)
+	}
+
+	sampleFormat := c.sampleFormat(codec)
+	if sampleFormat == avutil.SampleFormatNone {
+		return errors.Errorf("sample format must be set for %s", codec.Name())
+	}
+
+	if formats := codec.SampleFmts(); len(formats) > 0 && !containsSampleFormat(formats, sampleFormat) {
+		return errors.Errorf("sample format %s is not supported by %s", sampleFormat, codec.Name())
+	}
+
+	if rates := codec.SupportedSampleRates(); len(rates) > 0 && !containsInt32(rates, c.SampleRate) {
+		return errors.Errorf("sample rate %d is not supported by %s", c.SampleRate, codec.Name())
+	}
+
+	if layouts := codec.ChannelLayouts(); len(layouts) > 0 && !containsUint64(layouts, c.channelLayout()) {
+		return errors.Errorf("channel layout 0x%x is not supported by %s", c.channelLayout(), codec.Name())
+	}
+
+	return nil
+}
+
+// ApplyTo validates the config against the codec of the context and then sets
+// the fields and options of the context. It must be called before the context
+// is opened.
+func (c *AudioEncoderConfig) ApplyTo(ctx *EncoderContext) error {
+	if err := c.Validate(ctx.Codec()); err != nil {
+		return err
+	}
+
+	ctx.SampleFmt = c.sampleFormat(ctx.Codec())
+	ctx.SampleRate = c.SampleRate
+	ctx.ChannelLayout = c.channelLayout()
+	ctx.Channels = avutil.GetChannelLayoutNbChannels(ctx.ChannelLayout)
+	ctx.TimeBase = Rat(1, c.SampleRate)
+
+	if c.BitRate > 0 {
+		ctx.BitRate = c.BitRate
+	}
+
+	return applyEncoderConfig(ctx, c.Threads, c.GlobalHeader, c.Options)
+}
+
+// NewEncoderContext finds the encoder of the config and returns a new context
+// with the config applied.
+func (c *AudioEncoderConfig) NewEncoderContext() (*EncoderContext, error) {
+	return newEncoderContextFromConfig(c.Codec, c.ApplyTo)
+}
+
+func validateEncoder(codec *Codec, mediaType avutil.MediaType) error {
+	if !codec.IsEncoder() {
+		return errors.Errorf("%s is not an encoder", codec.Name())
+	}
+
+	if codec.Type != mediaType {
+		return errors.Errorf("%s is not a %s encoder", codec.Name(), mediaType)
+	}
+
+	return nil
+}
+
+func applyEncoderConfig(ctx *EncoderContext, threads int32, globalHeader bool, options map[string]string) error {
+	if threads > 0 {
+		ctx.ThreadCount = threads
+	}
+
+	if globalHeader {
+		ctx.Flags |= avcodec.FlagGlobalHeader
+	}
+
+	for name, value := range options {
+		if err := ctx.SetOption(name, value); err != nil {
+			return errors.WithMessagef(err, "option %s", name)
+		}
+	}
+
+	return nil
+}
+
+func newEncoderContextFromConfig(codecName string, apply func(*EncoderContext) error) (*EncoderContext, error) {
+	codec, err := FindEncoderCodecByName(codecName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := NewEncoderContext(codec, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(ctx); err != nil {
+		ctx.Free()
+		return nil, err
+	}
+
+	return ctx, nil
+}
+
+func containsPixelFormat(formats []avutil.PixelFormat, f avutil.PixelFormat) bool {
+	for _, v := range formats {
+		if v == f {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsSampleFormat(formats []avutil.SampleFormat, f avutil.SampleFormat) bool {
+	for _, v := range formats {
+		if v == f {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsRational(rates []avutil.Rational, q avutil.Rational) bool {
+	for _, v := range rates {
+		if v.Eq(q) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsInt32(values []int32, n int32) bool {
+	for _, v := range values {
+		if v == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsUint64(values []uint64, n uint64) bool {
+	for _, v := range values {
+		if v == n {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package av_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func TestAudioEncoderConfigDefaultSampleFormat(t *testing.T) {
+	// the zero sample format is left to the codec, aac does not support u8
+	config := av.AudioEncoderConfig{
+		Codec:      "aac",
+		SampleRate: 48000,
+		Channels:   2,
+	}
+
+	ctx, err := config.NewEncoderContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer ctx.Free()
+
+	if format := ctx.AudioFormat().SampleFormat; format != avutil.SampleFormatFLTP {
+		t.Errorf("got %s, want fltp", format)
+	}
+
+	if err := ctx.Open(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAudioEncoderConfigUnsupportedSampleFormat(t *testing.T) {
+	s16 := avutil.SampleFormatS16
+	config := av.AudioEncoderConfig{
+		Codec:        "aac",
+		SampleFormat: &s16,
+		SampleRate:   48000,
+		Channels:     2,
+	}
+
+	if _, err := config.NewEncoderContext(); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("got %v, want unsupported sample format", err)
+	}
+}
+
+func TestVideoEncoderConfigJSON(t *testing.T) {
+	var config av.VideoEncoderConfig
+	if err := json.Unmarshal([]byte(`{"codec":"mpeg4","width":320,"height":240,"frame_rate":"25/1"}`), &config); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.PixelFormat != nil {
+		t.Errorf("got pixel format %s, want nil", *config.PixelFormat)
+	}
+
+	ctx, err := config.NewEncoderContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer ctx.Free()
+
+	if err := ctx.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if ctx.TimeBase != avutil.Rat(1, 25) {
+		t.Errorf("got time base %s, want 1/25", ctx.TimeBase)
+	}
+
+	yuv420p := avutil.PixelFormatYUV420P
+	config.PixelFormat = &yuv420p
+
+	b, err := json.Marshal(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded av.VideoEncoderConfig
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.PixelFormat == nil || *decoded.PixelFormat != yuv420p || decoded.FrameRate != avutil.Rat(25, 1) {
+		t.Errorf("got %s after round trip", b)
+	}
+}
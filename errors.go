@@ -2,11 +2,16 @@ package av
 
 import (
 	"io"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avutil"
 )
 
+// ErrClosed is returned when a packet, frame or context is used after it was
+// closed or freed.
+var ErrClosed = errors.New("use of closed or freed object")
+
 func averror(code int32) error {
 	if code == 0 {
 		return nil
@@ -30,3 +35,47 @@ func avreturn(code int32) (int, error) {
 
 	return 0, averror(code)
 }
+
+// LogMessage is a message that was logged by libav.
+type LogMessage struct {
+	Level avutil.VerbosityLevel
+
+	// Item is the name of the component that logged the message, like "h264"
+	// or "mov,mp4".
+	Item    string
+	Message string
+}
+
+func (m LogMessage) String() string {
+	if m.Item == "" {
+		return m.Message
+	}
+
+	return m.Item + ": " + m.Message
+}
+
+// LogError is returned when opening an input or a codec fails. It holds the
+// warnings and errors that libav logged for the context during the call,
+// which usually explain the failure better than the error code.
+type LogError struct {
+	Err      error
+	Messages []LogMessage
+}
+
+func (e *LogError) Error() string {
+	messages := make([]string, len(e.Messages))
+	for i, m := range e.Messages {
+		messages[i] = m.String()
+	}
+
+	return e.Err.Error() + " (" + strings.Join(messages, "; ") + ")"
+}
+
+func (e *LogError) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns the underlying error for errors.Cause.
+func (e *LogError) Cause() error {
+	return e.Err
+}
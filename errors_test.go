@@ -0,0 +1,76 @@
+package av_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func TestLogMessageString(t *testing.T) {
+	tests := []struct {
+		name string
+		m    av.LogMessage
+		want string
+	}{
+		{"item", av.LogMessage{Level: avutil.Errors, Item: "h264", Message: "no frame!"}, "h264: no frame!"},
+		{"no item", av.LogMessage{Level: avutil.Warning, Message: "invalid data"}, "invalid data"},
+		{"empty", av.LogMessage{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogError(t *testing.T) {
+	cause := errors.New("open failed")
+
+	tests := []struct {
+		name     string
+		messages []av.LogMessage
+		want     string
+	}{
+		{
+			name:     "one message",
+			messages: []av.LogMessage{{Item: "mov,mp4", Message: "moov atom not found"}},
+			want:     "open failed (mov,mp4: moov atom not found)",
+		},
+		{
+			name: "many messages",
+			messages: []av.LogMessage{
+				{Item: "h264", Message: "no frame!"},
+				{Message: "invalid data"},
+			},
+			want: "open failed (h264: no frame!; invalid data)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.Wrap(&av.LogError{Err: cause, Messages: tt.messages}, "decode")
+
+			var logErr *av.LogError
+			if !errors.As(err, &logErr) {
+				t.Fatalf("errors.As(%v) = false", err)
+			}
+
+			if got := logErr.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+
+			if !errors.Is(err, cause) {
+				t.Errorf("errors.Is(%v, cause) = false", err)
+			}
+
+			if got := errors.Cause(err); got != cause {
+				t.Errorf("errors.Cause(%v) = %v, want %v", err, got, cause)
+			}
+		})
+	}
+}
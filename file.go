@@ -7,10 +7,12 @@ package av
 // extern int64_t goavFileSeek(void *opaque, int64_t offset, int whence);
 import "C"
 import (
+	"context"
 	"io"
 	"reflect"
 	"runtime"
 	"runtime/cgo"
+	"time"
 	"unsafe"
 
 	"github.com/pkg/errors"
@@ -23,14 +25,95 @@ type pinnedFile struct {
 	f interface{}
 
 	err error
+
+	// owner is the format context the file belongs to, if any.
+	owner *pinnedFormatContextData
+}
+
+func (f *pinnedFile) context() context.Context {
+	if f.owner == nil {
+		return nil
+	}
+
+	return f.owner.ctx
 }
 
 func (f *pinnedFile) Read(p []byte) (n int, err error) {
-	return f.f.(io.Reader).Read(p)
+	r := f.f.(io.Reader)
+
+	ctx := f.context()
+	if ctx == nil {
+		return r.Read(p)
+	}
+
+	var setDeadline func(time.Time) error
+	if d, ok := r.(interface{ SetReadDeadline(time.Time) error }); ok {
+		setDeadline = d.SetReadDeadline
+	}
+
+	return callWithDeadline(ctx, setDeadline, func() (int, error) {
+		return r.Read(p)
+	})
 }
 
 func (f *pinnedFile) Write(p []byte) (n int, err error) {
-	return f.f.(io.Writer).Write(p)
+	w := f.f.(io.Writer)
+
+	ctx := f.context()
+	if ctx == nil {
+		return w.Write(p)
+	}
+
+	var setDeadline func(time.Time) error
+	if d, ok := w.(interface{ SetWriteDeadline(time.Time) error }); ok {
+		setDeadline = d.SetWriteDeadline
+	}
+
+	return callWithDeadline(ctx, setDeadline, func() (int, error) {
+		return w.Write(p)
+	})
+}
+
+// callWithDeadline calls f unless ctx is already done. If setDeadline is not
+// nil, it is used to apply the deadline of ctx and to interrupt f when ctx is
+// canceled, like for net.Conn. The deadline is cleared once f returns, so that
+// later calls without a context are not affected.
+func callWithDeadline(ctx context.Context, setDeadline func(time.Time) error, f func() (int, error)) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if setDeadline == nil {
+		return f()
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := setDeadline(deadline); err != nil {
+		// deadlines are not supported, like for regular files
+		return f()
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-ctx.Done():
+			setDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	n, err := f()
+
+	// wait for the goroutine so that it can not set the deadline afterwards
+	close(done)
+	<-stopped
+
+	setDeadline(time.Time{})
+
+	return n, err
 }
 
 func (f *pinnedFile) Seek(offset int64, whence int) (int64, error) {
@@ -127,10 +210,10 @@ func allocAvioContext(f interface{}, writable bool) *avformat.IOContext {
 		writeFlag = 1
 	}
 
-	h := cgo.NewHandle(&pinnedFile{f: f})
+	h := newHandle(&pinnedFile{f: f})
 	ctx := avformat.NewIOContext((*byte)(avutil.Malloc(1<<12)), 1<<12, writeFlag, unsafe.Pointer(h), read, write, seek)
 	if ctx == nil {
-		h.Delete()
+		deleteHandle(h)
 		panic(avutil.ErrNoMem)
 	}
 
@@ -142,13 +225,24 @@ func newIOContext(f interface{}, writable bool) *ioContext {
 		_ioContext: allocAvioContext(f, writable),
 	}
 
-	runtime.SetFinalizer(ret, func(ctx *ioContext) {
-		cgo.Handle(ctx._ioContext.Opaque).Delete()
-		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
-		ioContext := (*avformat.IOContext)(ctx._ioContext)
-		avformat.FreeIOContext(&ioContext)
-		ctx._ioContext = ioContext
-	})
+	runtime.SetFinalizer(ret, freeIOContext)
 
 	return ret
 }
+
+func freeIOContext(ctx *ioContext) {
+	if ctx._ioContext == nil {
+		return
+	}
+
+	deleteHandle(cgo.Handle(ctx._ioContext.Opaque))
+	// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+	ioContext := (*avformat.IOContext)(ctx._ioContext)
+	avformat.FreeIOContext(&ioContext)
+	ctx._ioContext = ioContext
+}
+
+func (ctx *ioContext) free() {
+	runtime.SetFinalizer(ctx, nil)
+	freeIOContext(ctx)
+}
@@ -7,6 +7,7 @@ import (
 	"unsafe"
 
 	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avcodec"
 	"github.com/ssttevee/go-av/avfilter"
 	"github.com/ssttevee/go-av/avutil"
 )
@@ -32,6 +33,75 @@ func FindFilterByName(name string) (*Filter, error) {
 	return &Filter{_filter: filter}, nil
 }
 
+func (f *Filter) Name() string {
+	return f._filter.Name.String()
+}
+
+func (f *Filter) Description() string {
+	return f._filter.Description.String()
+}
+
+type FilterPad struct {
+	Name      string
+	MediaType avutil.MediaType
+}
+
+// Inputs returns the static inputs of the filter. Filters with the
+// avfilter.FlagDynamicInputs flag may have more inputs once they are created.
+func (f *Filter) Inputs() []FilterPad {
+	var pads []FilterPad
+	for i := int32(0); i < avfilter.CountPads(f._filter.Inputs); i++ {
+		pads = append(pads, FilterPad{
+			Name:      avfilter.GetPadName(f._filter.Inputs, i).String(),
+			MediaType: avutil.MediaType(avfilter.GetPadType(f._filter.Inputs, i)),
+		})
+	}
+
+	return pads
+}
+
+// Outputs returns the static outputs of the filter. Filters with the
+// avfilter.FlagDynamicOutputs flag may have more outputs once they are
+// created.
+func (f *Filter) Outputs() []FilterPad {
+	var pads []FilterPad
+	for i := int32(0); i < avfilter.CountPads(f._filter.Outputs); i++ {
+		pads = append(pads, FilterPad{
+			Name:      avfilter.GetPadName(f._filter.Outputs, i).String(),
+			MediaType: avutil.MediaType(avfilter.GetPadType(f._filter.Outputs, i)),
+		})
+	}
+
+	return pads
+}
+
+// FilterIterator iterates over the filters in libavfilter. The zero value is
+// ready to use.
+type FilterIterator struct {
+	opaque unsafe.Pointer
+}
+
+// Next returns the next filter, or nil once all filters have been returned.
+func (it *FilterIterator) Next() *Filter {
+	filter := avfilter.IterateFilters(&it.opaque)
+	if filter == nil {
+		return nil
+	}
+
+	return &Filter{_filter: filter}
+}
+
+// Filters returns all filters in libavfilter.
+func Filters() []*Filter {
+	var filters []*Filter
+	var it FilterIterator
+	for filter := it.Next(); filter != nil; filter = it.Next() {
+		filters = append(filters, filter)
+	}
+
+	return filters
+}
+
 type FilterInOut struct {
 	Name          string
 	FilterContext *FilterContext
@@ -55,16 +125,34 @@ func NewFilterGraph() (*FilterGraph, error) {
 
 	ret := &FilterGraph{_filterGraph: graph}
 
-	runtime.SetFinalizer(ret, func(graph *FilterGraph) {
-		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
-		filterGraph := (*avfilter.Graph)(graph._filterGraph)
-		avfilter.FreeGraph(&filterGraph)
-		graph._filterGraph = filterGraph
-	})
+	trackPointer(FilterGraphObject, unsafe.Pointer(graph))
+
+	runtime.SetFinalizer(ret, freeFilterGraph)
 
 	return ret, nil
 }
 
+func freeFilterGraph(g *FilterGraph) {
+	untrackPointer(FilterGraphObject, unsafe.Pointer(g._filterGraph))
+	setContextLogHandler(uintptr(unsafe.Pointer(g._filterGraph)), nil)
+	// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+	filterGraph := (*avfilter.Graph)(g._filterGraph)
+	avfilter.FreeGraph(&filterGraph)
+	g._filterGraph = filterGraph
+}
+
+// Free releases the graph and all of its filters without waiting for the
+// garbage collector. It is safe to call Free more than once and using the
+// graph or its filters afterwards returns ErrClosed.
+func (g *FilterGraph) Free() {
+	runtime.SetFinalizer(g, nil)
+	freeFilterGraph(g)
+}
+
+func (g *FilterGraph) freed() bool {
+	return g._filterGraph == nil
+}
+
 func (g *FilterGraph) wrapFilterIO(io *avfilter.InOut) *FilterInOut {
 	return &FilterInOut{
 		Name:          io.Name.String(),
@@ -74,6 +162,10 @@ func (g *FilterGraph) wrapFilterIO(io *avfilter.InOut) *FilterInOut {
 }
 
 func (g *FilterGraph) Parse(desc string) (inputs, outputs []*FilterInOut, _ error) {
+	if g.freed() {
+		return nil, nil, errors.WithStack(ErrClosed)
+	}
+
 	var cinputs, coutputs *avfilter.InOut
 	if err := averror(avfilter.ParseGraph(g._filterGraph, desc, &cinputs, &coutputs)); err != nil {
 		return nil, nil, err
@@ -94,6 +186,10 @@ func (g *FilterGraph) Parse(desc string) (inputs, outputs []*FilterInOut, _ erro
 }
 
 func (g *FilterGraph) init() error {
+	if g.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	g.initOnce.Do(func() {
 		g.initErr = averror(avfilter.ConfigGraph(g._filterGraph, nil))
 	})
@@ -116,6 +212,10 @@ func (g *FilterGraph) SetHWDeviceContext(ctx *HWDeviceContext) {
 }
 
 func (g *FilterGraph) newFilter(filter *Filter, name, args string) (*avfilter.Context, error) {
+	if g.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
 	var ctx *avfilter.Context
 	if err := averror(avfilter.CreateFilterGraph(&ctx, filter._filter, name, args, nil, g._filterGraph)); err != nil {
 		return nil, err
@@ -146,6 +246,10 @@ func (g *FilterGraph) NewFilterByName(filterName, name, args string) (*FilterCon
 }
 
 func linkFilters(src *FilterContext, srcPadIndex int32, dst *FilterContext, dstPadIndex int32) error {
+	if src.g.freed() || dst.g.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	return averror(avfilter.Link(src._filterContext, uint32(srcPadIndex), dst._filterContext, uint32(dstPadIndex)))
 }
 
@@ -164,6 +268,31 @@ func (ctx *FilterContext) Name() string {
 	return ctx._filterContext.Name.String()
 }
 
+// SetOption sets an option of the filter. Filters are initialized when they
+// are created, so options that are only read during initialization have no
+// effect.
+func (ctx *FilterContext) SetOption(name string, value interface{}) error {
+	if ctx.g.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	return setOption(unsafe.Pointer(ctx._filterContext), name, value, avutil.OptionSearchChildren)
+}
+
+func (ctx *FilterContext) GetOption(name string) (interface{}, error) {
+	if ctx.g.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
+	return getOption(unsafe.Pointer(ctx._filterContext), name, avutil.OptionSearchChildren)
+}
+
+// Options returns the generic options of the filter context followed by the
+// options of the filter.
+func (ctx *FilterContext) Options() []OptionInfo {
+	return listOptions(unsafe.Pointer(ctx._filterContext))
+}
+
 func (ctx *FilterContext) LinkFrom(padIndex int32, src *FilterContext, srcPadIndex int32) error {
 	return linkFilters(src, srcPadIndex, ctx, padIndex)
 }
@@ -171,6 +300,10 @@ func (ctx *FilterContext) LinkFrom(padIndex int32, src *FilterContext, srcPadInd
 type BufferSource FilterContext
 
 func (g *FilterGraph) NewBufferSource(name string, decoder *DecoderContext) (*BufferSource, error) {
+	if decoder.CodecType == avutil.Audio {
+		return g.NewAudioBufferSource(name, decoder.AudioFormat())
+	}
+
 	filter, err := FindFilterByName("buffer")
 	if err != nil {
 		return nil, err
@@ -214,11 +347,38 @@ func (g *FilterGraph) NewBufferSource(name string, decoder *DecoderContext) (*Bu
 	}, nil
 }
 
+// NewAudioBufferSource creates an abuffer filter that accepts frames of the
+// given format.
+func (g *FilterGraph) NewAudioBufferSource(name string, format AudioFormat) (*BufferSource, error) {
+	filter, err := FindFilterByName("abuffer")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := g.newFilter(filter, name, format.BufferSourceArgs())
+	if err != nil {
+		return nil, err
+	}
+
+	return &BufferSource{
+		g:              g,
+		_filterContext: ctx,
+	}, nil
+}
+
+// WriteFrame writes a frame to the source. A nil frame signals the end of the
+// stream, after which the remaining frames can be read from the sinks.
 func (src *BufferSource) WriteFrame(frame *Frame) error {
 	if err := src.g.init(); err != nil {
 		return err
 	}
 
+	if frame == nil {
+		return averror(avfilter.WriteBufferSourceFrame(src._filterContext, nil))
+	} else if frame.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	defer runtime.KeepAlive(frame)
 
 	return averror(avfilter.WriteBufferSourceFrame(src._filterContext, frame._frame))
@@ -247,11 +407,101 @@ func (g *FilterGraph) NewBufferSink(name string) (*BufferSink, error) {
 	}, nil
 }
 
+// NewAudioBufferSink creates an abuffersink filter.
+func (g *FilterGraph) NewAudioBufferSink(name string) (*BufferSink, error) {
+	filter, err := FindFilterByName("abuffersink")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := g.newFilter(filter, name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &BufferSink{
+		g:              g,
+		_filterContext: ctx,
+	}, nil
+}
+
+// SetFrameSize makes the audio sink output frames with exactly n samples,
+// except for the last frame. This is required for encoders that only accept
+// fixed size frames, like aac.
+func (sink *BufferSink) SetFrameSize(n int32) error {
+	if err := sink.g.init(); err != nil {
+		return err
+	}
+
+	avfilter.SetBufferSinkFrameSize(sink._filterContext, uint32(n))
+
+	return nil
+}
+
+// SetFrameSizeForEncoder sets the frame size to the encoder's frame size if
+// the encoder does not support variable frame sizes.
+func (sink *BufferSink) SetFrameSizeForEncoder(encoder *EncoderContext) error {
+	if encoder.FrameSize == 0 || encoder.Codec().Capabilities&avcodec.CapabilityVariableFrameSize != 0 {
+		return nil
+	}
+
+	return sink.SetFrameSize(encoder.FrameSize)
+}
+
+func (sink *BufferSink) TimeBase() (avutil.Rational, error) {
+	if err := sink.g.init(); err != nil {
+		return avutil.Rational{}, err
+	}
+
+	return avfilter.GetBufferSinkTimeBase(sink._filterContext), nil
+}
+
+// FrameRate returns the frame rate of the frames produced by a video sink. It
+// is zero if the frame rate is unknown or variable.
+func (sink *BufferSink) FrameRate() (avutil.Rational, error) {
+	if err := sink.g.init(); err != nil {
+		return avutil.Rational{}, err
+	}
+
+	return avfilter.GetBufferSinkFrameRate(sink._filterContext), nil
+}
+
+// AudioFormat returns the format of the frames produced by an audio sink.
+func (sink *BufferSink) AudioFormat() (AudioFormat, error) {
+	if err := sink.g.init(); err != nil {
+		return AudioFormat{}, err
+	}
+
+	return AudioFormat{
+		SampleFormat:  avutil.SampleFormat(avfilter.GetBufferSinkFormat(sink._filterContext)),
+		SampleRate:    avfilter.GetBufferSinkSampleRate(sink._filterContext),
+		ChannelLayout: avfilter.GetBufferSinkChannelLayout(sink._filterContext),
+		TimeBase:      avfilter.GetBufferSinkTimeBase(sink._filterContext),
+	}, nil
+}
+
+// VideoFormat returns the format of the frames produced by a video sink.
+func (sink *BufferSink) VideoFormat() (VideoFormat, error) {
+	if err := sink.g.init(); err != nil {
+		return VideoFormat{}, err
+	}
+
+	return VideoFormat{
+		Width:       avfilter.GetBufferSinkWidth(sink._filterContext),
+		Height:      avfilter.GetBufferSinkHeight(sink._filterContext),
+		PixelFormat: avutil.PixelFormat(avfilter.GetBufferSinkFormat(sink._filterContext)),
+	}, nil
+}
+
 func (sink *BufferSink) ReadFrameReuse(frame *Frame) error {
 	if err := sink.g.init(); err != nil {
 		return err
 	}
 
+	if frame.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	return averror(avfilter.GetBufferSinkFrame(sink._filterContext, frame.prepare()))
 }
 
@@ -266,6 +516,21 @@ func (sink *BufferSink) ReadFrame() (*Frame, error) {
 	return frame, nil
 }
 
+// ReadFrameWithPool is like ReadFrame, but the frame is taken from pool.
+func (sink *BufferSink) ReadFrameWithPool(pool *FramePool) (*Frame, error) {
+	frame := pool.Get()
+	if err := sink.ReadFrameReuse(frame); err != nil {
+		pool.Put(frame)
+		if errors.Is(err, avutil.ErrAgain) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return frame, nil
+}
+
 func (sink *BufferSink) ReadFrames() ([]*Frame, error) {
 	var frames []*Frame
 	for {
@@ -1,13 +1,17 @@
 package av
 
+// #include <libavformat/avformat.h>
+//
 // struct AVFormatContext;
 // struct AVIOContext;
 // struct AVDictionary;
 //
 // extern int goavIOOpen(struct AVFormatContext *s, struct AVIOContext **pb, char *url, int flags, struct AVDictionary **options);
 // extern int goavIOClose(struct AVFormatContext *s, struct AVIOContext *pb);
+// extern int goavInterruptCallback(void *opaque);
 import "C"
 import (
+	"context"
 	"io"
 	"io/ioutil"
 	"net/url"
@@ -92,6 +96,10 @@ func (nullOpener) Open(inputURL string, flags int) (io.Closer, error) {
 type pinnedFormatContextData struct {
 	opener Opener
 	err    error
+
+	// ctx is the context of the blocking call that is currently in progress,
+	// if any.
+	ctx context.Context
 }
 
 func unwrapPinnedFormatContextDataEntries(p unsafe.Pointer) *pinnedFormatContextData {
@@ -103,6 +111,17 @@ func returnPinnedFormatContextDataError(p unsafe.Pointer, err error) C.int {
 	return C.int(common.FormatError)
 }
 
+//export goavInterruptCallback
+func goavInterruptCallback(opaque unsafe.Pointer) C.int {
+	defer runtime.KeepAlive(opaque)
+
+	if ctx := unwrapPinnedFormatContextDataEntries(opaque).ctx; ctx != nil && ctx.Err() != nil {
+		return 1
+	}
+
+	return 0
+}
+
 //export goavIOOpen
 func goavIOOpen(s *C.struct_AVFormatContext, pb **C.struct_AVIOContext, url *C.char, flags C.int, options **C.struct_AVDictionary) C.int {
 	var goflags int
@@ -122,7 +141,10 @@ func goavIOOpen(s *C.struct_AVFormatContext, pb **C.struct_AVIOContext, url *C.c
 		return returnPinnedFormatContextDataError(opaque, err)
 	}
 
-	*pb = (*C.struct_AVIOContext)(unsafe.Pointer(allocAvioContext(f, writable)))
+	ioctx := allocAvioContext(f, writable)
+	unwrapPinnedFile(ioctx.Opaque).owner = unwrapPinnedFormatContextDataEntries(opaque)
+
+	*pb = (*C.struct_AVIOContext)(unsafe.Pointer(ioctx))
 
 	return 0
 }
@@ -136,7 +158,7 @@ func goavIOClose(s *C.struct_AVFormatContext, pb *C.struct_AVIOContext) C.int {
 		return returnPinnedFormatContextDataError((*avformat.Context)(unsafe.Pointer(s)).Opaque, err)
 	}
 
-	cgo.Handle(opaque).Delete()
+	deleteHandle(cgo.Handle(opaque))
 
 	return 0
 }
@@ -151,21 +173,76 @@ type formatContext struct {
 
 func (ctx *formatContext) pinnedData() *pinnedFormatContextData {
 	ctx.pinnedDataOnce.Do(func() {
-		ctx.Opaque = unsafe.Pointer(cgo.NewHandle(&pinnedFormatContextData{}))
+		ctx.Opaque = unsafe.Pointer(newHandle(&pinnedFormatContextData{}))
+
+		interruptCallback := &(*C.struct_AVFormatContext)(unsafe.Pointer(ctx._formatContext)).interrupt_callback
+		interruptCallback.callback = (*[0]byte)(C.goavInterruptCallback)
+		interruptCallback.opaque = ctx.Opaque
 	})
 
 	return unwrapPinnedFormatContextDataEntries(ctx.Opaque)
 }
 
-func (ctx *formatContext) finalizePinnedData() {
-	if ctx.Opaque == nil {
-		return
+// withContext calls f with the interrupt callback tied to c, so that blocking
+// operations are aborted once c is done. The error of c is returned instead
+// of the error of f if c is done.
+func (ctx *formatContext) withContext(c context.Context, f func() error) error {
+	if err := c.Err(); err != nil {
+		return err
 	}
 
-	cgo.Handle(ctx.Opaque).Delete()
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	// f may free the context, so keep a reference to the pinned data instead
+	data := ctx.pinnedData()
+	data.ctx = c
+	defer func() {
+		data.ctx = nil
+	}()
+
+	if err := f(); err != nil {
+		if ctxErr := c.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (ctx *formatContext) freed() bool {
+	return ctx._formatContext == nil
+}
+
+// finalizePinnedData calls free to free the context and releases the pinned
+// data afterwards. Closing a context may still report io errors through the
+// pinned data, so the handle is only deleted once free returns, but the
+// interrupt callback is removed beforehand.
+func (ctx *formatContext) finalizePinnedData(free func()) {
+	key := uintptr(unsafe.Pointer(ctx._formatContext))
+	opaque := ctx.Opaque
+	if opaque != nil {
+		interruptCallback := &(*C.struct_AVFormatContext)(unsafe.Pointer(ctx._formatContext)).interrupt_callback
+		interruptCallback.callback = nil
+		interruptCallback.opaque = nil
+	}
+
+	free()
+
+	setContextLogHandler(key, nil)
+	if opaque != nil {
+		deleteHandle(cgo.Handle(opaque))
+	}
 }
 
 func (ctx *formatContext) FindBestStream(mediaType avutil.MediaType) (int, *Codec, error) {
+	if ctx.freed() {
+		return 0, nil, errors.WithStack(ErrClosed)
+	}
+
 	var codec *avcodec.Codec
 	streamIndex, err := avreturn(avformat.FindBestStream(ctx._formatContext, mediaType, -1, -1, &codec, 0))
 	if errors.Is(err, avutil.ErrStreamNotFound) {
@@ -178,22 +255,55 @@ func (ctx *formatContext) FindBestStream(mediaType avutil.MediaType) (int, *Code
 }
 
 func (ctx *formatContext) GuessFramerate(stream *Stream) avutil.Rational {
+	if ctx.freed() {
+		return avutil.Rational{}
+	}
+
 	return avformat.GuessFrameRate(ctx._formatContext, stream._stream, nil)
 }
 
+// SetOption sets a generic or format specific option of the context.
 func (ctx *formatContext) SetOption(name string, value interface{}) error {
-	return setOption(ctx.PrivData, name, value, 0)
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	return setOption(unsafe.Pointer(ctx._formatContext), name, value, avutil.OptionSearchChildren)
 }
 
+// GetOption returns the value of a generic or format specific option of the
+// context.
 func (ctx *formatContext) GetOption(name string) (interface{}, error) {
-	return getOption(ctx.PrivData, name, 0)
+	if ctx.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
+	return getOption(unsafe.Pointer(ctx._formatContext), name, avutil.OptionSearchChildren)
+}
+
+// Options returns the generic options of the context followed by the format
+// specific options.
+func (ctx *formatContext) Options() []OptionInfo {
+	if ctx.freed() {
+		return nil
+	}
+
+	return listOptions(unsafe.Pointer(ctx._formatContext))
 }
 
 func (ctx *formatContext) Filename() string {
+	if ctx.freed() {
+		return ""
+	}
+
 	return (&ctx._formatContext.Filename[0]).String()
 }
 
 func (ctx *formatContext) SetFilename(name string) {
+	if ctx.freed() {
+		return
+	}
+
 	if len(name) > len(ctx._formatContext.Filename) {
 		panic("filename too long")
 	}
@@ -205,6 +315,10 @@ func (ctx *formatContext) SetFilename(name string) {
 }
 
 func (ctx *formatContext) streams() []*avformat.Stream {
+	if ctx.freed() {
+		return nil
+	}
+
 	return *(*[]*avformat.Stream)(unsafe.Pointer(&reflect.SliceHeader{Data: uintptr(unsafe.Pointer(ctx._formatContext.Streams)), Len: int(ctx.NbStreams), Cap: int(ctx.NbStreams)}))
 }
 
@@ -221,7 +335,13 @@ func (ctx *formatContext) Streams() []*Stream {
 	return ret
 }
 
+// Stream returns the i-th stream of the context, or nil if the context was
+// closed.
 func (ctx *formatContext) Stream(i int) *Stream {
+	if ctx.freed() {
+		return nil
+	}
+
 	return &Stream{
 		_stream:   ctx.streams()[i],
 		formatCtx: ctx._formatContext,
@@ -229,10 +349,18 @@ func (ctx *formatContext) Stream(i int) *Stream {
 }
 
 func (ctx *formatContext) Url() string {
+	if ctx.freed() {
+		return ""
+	}
+
 	return ctx._formatContext.Url.String()
 }
 
 func (ctx *formatContext) SetUrl(url string) {
+	if ctx.freed() {
+		return
+	}
+
 	if ctx._formatContext.Url != nil {
 		avutil.Free(unsafe.Pointer(ctx._formatContext.Url))
 	}
@@ -241,6 +369,10 @@ func (ctx *formatContext) SetUrl(url string) {
 }
 
 func (ctx *formatContext) SetOpener(opener Opener) {
+	if ctx.freed() {
+		return
+	}
+
 	ctx.pinnedData().opener = opener
 	ctx.IoOpen = (*[0]byte)(C.goavIOOpen)
 	ctx.IoClose = (*[0]byte)(C.goavIOClose)
@@ -1,8 +1,11 @@
 package av
 
 import (
+	"reflect"
 	"runtime"
+	"unsafe"
 
+	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avutil"
 )
 
@@ -22,16 +25,77 @@ func NewFrame() *Frame {
 		_frame: frame,
 	}
 
-	runtime.SetFinalizer(ret, func(f *Frame) {
-		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
-		frame := (*avutil.Frame)(f._frame)
-		avutil.FreeFrame(&frame)
-		f._frame = frame
-	})
+	trackPointer(FrameObject, unsafe.Pointer(frame))
+	runtime.SetFinalizer(ret, freeFrame)
 
 	return ret
 }
 
+func freeFrame(f *Frame) {
+	untrackPointer(FrameObject, unsafe.Pointer(f._frame))
+	// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+	frame := (*avutil.Frame)(f._frame)
+	avutil.FreeFrame(&frame)
+	f._frame = frame
+}
+
+// Free releases the frame and its references to the data buffers without
+// waiting for the garbage collector. It is safe to call Free more than once,
+// but the frame must not be used afterwards.
+func (f *Frame) Free() {
+	runtime.SetFinalizer(f, nil)
+	freeFrame(f)
+}
+
+func (f *Frame) freed() bool {
+	return f._frame == nil
+}
+
+// NewVideoFrame allocates a frame with a buffer for a picture of the given
+// size and pixel format.
+func NewVideoFrame(width, height int32, format avutil.PixelFormat) (*Frame, error) {
+	frame := NewFrame()
+	frame._frame.Width = width
+	frame._frame.Height = height
+	frame._frame.Format = int32(format)
+
+	if err := averror(avutil.GetFrameBuffer(frame._frame, 0)); err != nil {
+		frame.Free()
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// NewAudioFrame allocates a frame with a buffer for nbSamples samples per
+// channel of the given format.
+func NewAudioFrame(nbSamples int32, format avutil.SampleFormat, channelLayout uint64, sampleRate int32) (*Frame, error) {
+	frame := NewFrame()
+	frame._frame.NbSamples = nbSamples
+	frame._frame.Format = int32(format)
+	frame._frame.ChannelLayout = channelLayout
+	frame._frame.Channels = avutil.GetChannelLayoutNbChannels(channelLayout)
+	frame._frame.SampleRate = sampleRate
+
+	if err := averror(avutil.GetFrameBuffer(frame._frame, 0)); err != nil {
+		frame.Free()
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// MakeWritable makes sure that the data of the frame is not shared with any
+// other frame, copying it if necessary. It should be called before modifying
+// a frame that may still be referenced elsewhere, like by an encoder.
+func (f *Frame) MakeWritable() error {
+	if f.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	return averror(avutil.MakeFrameWritable(f._frame))
+}
+
 func (f *Frame) prepare() *avutil.Frame {
 	f.Unref()
 
@@ -39,16 +103,25 @@ func (f *Frame) prepare() *avutil.Frame {
 }
 
 func (f *Frame) Unref() {
+	if f.freed() {
+		return
+	}
+
 	avutil.UnrefFrame(f._frame)
 }
 
 func (f *Frame) CopyTo(f2 *Frame) error {
+	if f.freed() || f2.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	return averror(avutil.RefFrame(f._frame, f2._frame))
 }
 
 func (f *Frame) Clone() (*Frame, error) {
 	clone := NewFrame()
 	if err := f.CopyTo(clone); err != nil {
+		clone.Free()
 		return nil, err
 	}
 
@@ -56,9 +129,184 @@ func (f *Frame) Clone() (*Frame, error) {
 }
 
 func (f *Frame) HwFramesCtx() *HWFramesContext {
-	if f._frame.HwFramesCtx == nil {
+	if f.freed() || f._frame.HwFramesCtx == nil {
 		return nil
 	}
 
 	return newHWFramesContext(avutil.RefBuffer(f._frame.HwFramesCtx))
 }
+
+func (f *Frame) isVideo() bool {
+	return !f.freed() && f._frame.Width > 0 && f._frame.Height > 0
+}
+
+// planeSize returns the size in bytes of the i-th plane of the frame.
+func (f *Frame) planeSize(i int) int {
+	if f.freed() {
+		return 0
+	}
+
+	if f.isVideo() {
+		format := avutil.PixelFormat(f._frame.Format)
+		if i >= format.Planes() || i >= len(f._frame.Linesize) {
+			return 0
+		}
+
+		height := int(f._frame.Height)
+		if i == 1 || i == 2 {
+			_, shift := format.ChromaSubsample()
+			height = (height + 1<<shift - 1) >> shift
+		}
+
+		return int(f._frame.Linesize[i]) * height
+	}
+
+	format := avutil.SampleFormat(f._frame.Format)
+	size := int(f._frame.NbSamples) * format.BytesPerSample()
+	if format.IsPlanar() {
+		if i >= int(f._frame.Channels) {
+			return 0
+		}
+
+		return size
+	}
+
+	if i > 0 {
+		return 0
+	}
+
+	return size * int(f._frame.Channels)
+}
+
+// Planes returns the number of data planes of the frame.
+func (f *Frame) Planes() int {
+	if f.freed() {
+		return 0
+	}
+
+	if f.isVideo() {
+		return avutil.PixelFormat(f._frame.Format).Planes()
+	}
+
+	if avutil.SampleFormat(f._frame.Format).IsPlanar() {
+		return int(f._frame.Channels)
+	}
+
+	return 1
+}
+
+// Plane returns the data of the i-th plane of the frame, including the
+// padding at the end of each line for video frames. The slice refers to the
+// memory of the frame, so it is only valid until the frame is unreferenced or
+// freed. It returns nil for hardware frames and frames with negative
+// linesizes.
+func (f *Frame) Plane(i int) []byte {
+	if f.freed() || f._frame.HwFramesCtx != nil || i < 0 {
+		return nil
+	}
+
+	var data unsafe.Pointer
+	if f.isVideo() {
+		if i >= len(f._frame.Data) || f._frame.Linesize[i] < 0 {
+			return nil
+		}
+
+		data = unsafe.Pointer(f._frame.Data[i])
+	} else if i < f.Planes() && f._frame.ExtendedData != nil {
+		// audio frames with many channels only fit in the extended data
+		data = unsafe.Pointer(*(**uint8)(unsafe.Pointer(uintptr(unsafe.Pointer(f._frame.ExtendedData)) + uintptr(i)*unsafe.Sizeof(f._frame.Data[0]))))
+	}
+
+	size := f.planeSize(i)
+	if data == nil || size <= 0 {
+		return nil
+	}
+
+	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
+		Data: uintptr(data),
+		Len:  size,
+		Cap:  size,
+	}))
+}
+
+// Samples returns the samples of an audio frame as one slice per plane, typed
+// according to the sample format: [][]uint8, [][]int16, [][]int32, [][]int64,
+// [][]float32 or [][]float64. Planar formats have one plane per channel while
+// packed formats have a single plane with interleaved channels. Like Plane,
+// the slices refer to the memory of the frame.
+func (f *Frame) Samples() (interface{}, error) {
+	if f.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
+	if f.isVideo() {
+		return nil, errors.New("not an audio frame")
+	}
+
+	planes := make([][]byte, f.Planes())
+	for i := range planes {
+		planes[i] = f.Plane(i)
+	}
+
+	switch avutil.SampleFormat(f._frame.Format) {
+	case avutil.SampleFormatU8, avutil.SampleFormatU8P:
+		return planes, nil
+
+	case avutil.SampleFormatS16, avutil.SampleFormatS16P:
+		ret := make([][]int16, len(planes))
+		for i, plane := range planes {
+			ret[i] = *(*[]int16)(castSlice(plane, 2))
+		}
+
+		return ret, nil
+
+	case avutil.SampleFormatS32, avutil.SampleFormatS32P:
+		ret := make([][]int32, len(planes))
+		for i, plane := range planes {
+			ret[i] = *(*[]int32)(castSlice(plane, 4))
+		}
+
+		return ret, nil
+
+	case avutil.SampleFormatS64, avutil.SampleFormatS64P:
+		ret := make([][]int64, len(planes))
+		for i, plane := range planes {
+			ret[i] = *(*[]int64)(castSlice(plane, 8))
+		}
+
+		return ret, nil
+
+	case avutil.SampleFormatFLT, avutil.SampleFormatFLTP:
+		ret := make([][]float32, len(planes))
+		for i, plane := range planes {
+			ret[i] = *(*[]float32)(castSlice(plane, 4))
+		}
+
+		return ret, nil
+
+	case avutil.SampleFormatDBL, avutil.SampleFormatDBLP:
+		ret := make([][]float64, len(planes))
+		for i, plane := range planes {
+			ret[i] = *(*[]float64)(castSlice(plane, 8))
+		}
+
+		return ret, nil
+	}
+
+	return nil, errors.Errorf("unsupported sample format: %s", avutil.SampleFormat(f._frame.Format))
+}
+
+// castSlice returns a pointer to a slice header for b reinterpreted as
+// elements of the given size.
+func castSlice(b []byte, elemSize int) unsafe.Pointer {
+	var data uintptr
+	if len(b) > 0 {
+		data = uintptr(unsafe.Pointer(&b[0]))
+	}
+
+	return unsafe.Pointer(&reflect.SliceHeader{
+		Data: data,
+		Len:  len(b) / elemSize,
+		Cap:  len(b) / elemSize,
+	})
+}
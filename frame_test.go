@@ -0,0 +1,46 @@
+package av_test
+
+import (
+	"testing"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func TestNewVideoFrame(t *testing.T) {
+	frame, err := av.NewVideoFrame(7, 5, avutil.PixelFormatYUV420P)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer frame.Free()
+
+	if frame.Planes() != 3 || len(frame.Plane(0)) < 7*5 {
+		t.Errorf("got %d planes of which the first has %d bytes", frame.Planes(), len(frame.Plane(0)))
+	}
+
+	if _, err := av.NewVideoFrame(0, 5, avutil.PixelFormatYUV420P); err == nil {
+		t.Error("expected an error for a frame without width")
+	}
+
+	if _, err := av.NewVideoFrame(7, 5, avutil.PixelFormatNone); err == nil {
+		t.Error("expected an error for a frame without pixel format")
+	}
+}
+
+func TestNewAudioFrame(t *testing.T) {
+	frame, err := av.NewAudioFrame(1024, avutil.SampleFormatFLTP, stereo, 48000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer frame.Free()
+
+	if frame.Planes() != 2 || len(frame.Plane(0)) < 1024*4 {
+		t.Errorf("got %d planes of which the first has %d bytes", frame.Planes(), len(frame.Plane(0)))
+	}
+
+	if _, err := av.NewAudioFrame(0, avutil.SampleFormatFLTP, stereo, 48000); err == nil {
+		t.Error("expected an error for a frame without samples")
+	}
+}
@@ -0,0 +1,153 @@
+package av_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avcodec"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func assertClosed(t *testing.T, what string, err error) {
+	t.Helper()
+
+	if !errors.Is(err, av.ErrClosed) {
+		t.Errorf("%s: got %v, want ErrClosed", what, err)
+	}
+}
+
+func TestPacketUseAfterFree(t *testing.T) {
+	packet, err := av.NewPacketFromBytes([]byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packet.SetKeyframe(true)
+	packet.Free()
+	packet.Free()
+
+	if packet.IsKeyframe() || packet.IsCorrupt() || packet.IsDiscarded() {
+		t.Error("freed packet has flags")
+	}
+
+	packet.SetKeyframe(false)
+	packet.RemoveSideData(avcodec.PacketSideDataDisplayMatrix)
+
+	if packet.Bytes() != nil || packet.SideData() != nil {
+		t.Error("freed packet has data")
+	}
+
+	_, err = packet.AddSideData(avcodec.PacketSideDataDisplayMatrix, av.NewDisplayMatrix(90).Bytes())
+	assertClosed(t, "AddSideData", err)
+
+	_, err = packet.Clone()
+	assertClosed(t, "Clone", err)
+}
+
+func TestFrameUseAfterFree(t *testing.T) {
+	frame, err := av.NewVideoFrame(16, 16, avutil.PixelFormatYUV420P)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frame.Free()
+	frame.Free()
+
+	if frame.Planes() != 0 || frame.Plane(0) != nil || frame.Metadata() != nil || frame.SideData() != nil {
+		t.Error("freed frame has data")
+	}
+
+	frame.RemoveSideData(avutil.FrameSideDataDisplayMatrix)
+
+	_, err = frame.Image()
+	assertClosed(t, "Image", err)
+
+	_, err = frame.Samples()
+	assertClosed(t, "Samples", err)
+
+	assertClosed(t, "MakeWritable", frame.MakeWritable())
+	assertClosed(t, "SetMetadataValue", frame.SetMetadataValue("key", "value"))
+
+	_, err = frame.Clone()
+	assertClosed(t, "Clone", err)
+}
+
+func TestBitstreamFilterContextUseAfterFree(t *testing.T) {
+	filter, err := av.FindBitstreamFilterByName("null")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, err := av.NewBitstreamFilterContext(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx.Free()
+	ctx.Free()
+
+	ctx.SetInputTimeBase(avutil.Rat(1, 90000))
+
+	if ctx.OutputCodecParameters() != nil || ctx.OutputTimeBase() != (avutil.Rational{}) || ctx.Options() != nil {
+		t.Error("freed bitstream filter has parameters")
+	}
+
+	assertClosed(t, "Init", ctx.Init())
+
+	_, err = ctx.FilterPacket(nil)
+	assertClosed(t, "FilterPacket", err)
+}
+
+func TestInputUseAfterClose(t *testing.T) {
+	input := openTestMedia(t)
+	stream := input.Stream(0)
+
+	codec, err := av.FindDecoderCodecByID(stream.Codecpar().CodecID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder, err := av.NewDecoderContext(codec, stream.Codecpar())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := input.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := input.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = input.ReadPacket()
+	assertClosed(t, "ReadPacket", err)
+	assertClosed(t, "SeekTo", input.SeekTo(0))
+
+	if len(input.Streams()) != 0 || input.Stream(0) != nil {
+		t.Error("closed input has streams")
+	}
+
+	decoder.Free()
+	decoder.Free()
+
+	assertClosed(t, "SendPacket", decoder.SendPacket(nil))
+}
+
+func TestOutputUseAfterFree(t *testing.T) {
+	output, err := av.NewOutputContext("null")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output.Free()
+	output.Free()
+
+	assertClosed(t, "WriteHeader", output.WriteHeader())
+	assertClosed(t, "WritePacket", output.WritePacket(nil))
+
+	if err := output.Close(); err != nil {
+		t.Errorf("Close after Free: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"runtime"
 	"unsafe"
 
+	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avutil"
 )
 
@@ -16,16 +17,26 @@ type HWDeviceContext struct {
 func newHWDeviceContext(ctx *avutil.BufferRef) *HWDeviceContext {
 	ret := &HWDeviceContext{ctx: ctx}
 
-	runtime.SetFinalizer(ret, func(ctx *HWDeviceContext) {
-		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
-		buf := (*avutil.BufferRef)(ctx.ctx)
-		avutil.UnrefBuffer(&buf)
-		ctx.ctx = buf
-	})
+	runtime.SetFinalizer(ret, freeHWDeviceContext)
 
 	return ret
 }
 
+func freeHWDeviceContext(ctx *HWDeviceContext) {
+	// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+	buf := (*avutil.BufferRef)(ctx.ctx)
+	avutil.UnrefBuffer(&buf)
+	ctx.ctx = buf
+}
+
+// Free releases this reference to the device without waiting for the garbage
+// collector. The device itself is only closed once codecs, filters and frame
+// pools that use it are freed as well. It is safe to call Free more than once.
+func (ctx *HWDeviceContext) Free() {
+	runtime.SetFinalizer(ctx, nil)
+	freeHWDeviceContext(ctx)
+}
+
 func NewHWDeviceContext(deviceType avutil.HWDeviceType, device string) (*HWDeviceContext, error) {
 	var ctx *avutil.BufferRef
 	if err := averror(avutil.NewHWDeviceContext(&ctx, deviceType, device, nil, 0)); err != nil {
@@ -36,6 +47,10 @@ func NewHWDeviceContext(deviceType avutil.HWDeviceType, device string) (*HWDevic
 }
 
 func (ctx *HWDeviceContext) ref() *avutil.BufferRef {
+	if ctx.ctx == nil {
+		panic(errors.WithStack(ErrClosed))
+	}
+
 	ref := avutil.RefBuffer(ctx.ctx)
 	if ref == nil {
 		panic(avutil.ErrNoMem)
@@ -73,6 +88,10 @@ func newHWFramesContext(buf *avutil.BufferRef) *HWFramesContext {
 }
 
 func NewHWFramesContext(deviceCtx *HWDeviceContext) *HWFramesContext {
+	if deviceCtx.ctx == nil {
+		panic(errors.WithStack(ErrClosed))
+	}
+
 	return newHWFramesContext(avutil.NewHWFramesContext(deviceCtx.ctx))
 }
 
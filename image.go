@@ -0,0 +1,217 @@
+package av
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// yCbCrSubsampleRatios maps planar yuv formats to the image package's
+// subsample ratios. yuv410p is missing because its chroma planes have a
+// quarter of the rows, but image.YCbCrSubsampleRatio410 has half of them.
+var yCbCrSubsampleRatios = map[avutil.PixelFormat]image.YCbCrSubsampleRatio{
+	avutil.PixelFormatYUV420P:  image.YCbCrSubsampleRatio420,
+	avutil.PixelFormatYUVJ420P: image.YCbCrSubsampleRatio420,
+	avutil.PixelFormatYUV422P:  image.YCbCrSubsampleRatio422,
+	avutil.PixelFormatYUVJ422P: image.YCbCrSubsampleRatio422,
+	avutil.PixelFormatYUV444P:  image.YCbCrSubsampleRatio444,
+	avutil.PixelFormatYUVJ444P: image.YCbCrSubsampleRatio444,
+	avutil.PixelFormatYUV440P:  image.YCbCrSubsampleRatio440,
+	avutil.PixelFormatYUVJ440P: image.YCbCrSubsampleRatio440,
+	avutil.PixelFormatYUV411P:  image.YCbCrSubsampleRatio411,
+}
+
+// yCbCrPixelFormats are the pixel formats used for images with the image
+// package's full range ycbcr. The yuvj formats are deprecated in favor of
+// setting the color range of the frame.
+var yCbCrPixelFormats = map[image.YCbCrSubsampleRatio]avutil.PixelFormat{
+	image.YCbCrSubsampleRatio420: avutil.PixelFormatYUV420P,
+	image.YCbCrSubsampleRatio422: avutil.PixelFormatYUV422P,
+	image.YCbCrSubsampleRatio444: avutil.PixelFormatYUV444P,
+	image.YCbCrSubsampleRatio440: avutil.PixelFormatYUV440P,
+	image.YCbCrSubsampleRatio411: avutil.PixelFormatYUV411P,
+}
+
+// fullRangePixelFormats are the deprecated yuv formats that are always full
+// range, regardless of the color range of the frame.
+var fullRangePixelFormats = map[avutil.PixelFormat]bool{
+	avutil.PixelFormatYUVJ420P: true,
+	avutil.PixelFormatYUVJ422P: true,
+	avutil.PixelFormatYUVJ444P: true,
+	avutil.PixelFormatYUVJ440P: true,
+}
+
+// lumaToFullRange and chromaToFullRange map limited range samples, where luma
+// is from 16 to 235 and chroma from 16 to 240, to full range samples.
+var lumaToFullRange, chromaToFullRange = func() (luma, chroma [256]uint8) {
+	for i := range luma {
+		luma[i] = clampUint8((float64(i) - 16) * 255 / 219)
+		chroma[i] = clampUint8((float64(i)-128)*255/224 + 128)
+	}
+
+	return luma, chroma
+}()
+
+func clampUint8(v float64) uint8 {
+	if v <= 0 {
+		return 0
+	} else if v >= 255 {
+		return 255
+	}
+
+	return uint8(v + 0.5)
+}
+
+func mapSamples(samples []byte, table *[256]uint8) {
+	for i, v := range samples {
+		samples[i] = table[v]
+	}
+}
+
+// copyPlane copies the rows of a frame plane into an image plane.
+func copyPlane(dst []byte, dstStride int, src []byte, srcStride int, rowSize, rows int) {
+	for y := 0; y < rows; y++ {
+		copy(dst[y*dstStride:y*dstStride+rowSize], src[y*srcStride:y*srcStride+rowSize])
+	}
+}
+
+// Image copies the pixels of a video frame into an image. Planar yuv formats
+// are converted to *image.YCbCr, gray8 to *image.Gray, rgba to *image.NRGBA
+// since libav does not premultiply alpha, and rgb0 to *image.RGBA. Other
+// formats must be converted with a Scaler first. Limited range yuv samples
+// are expanded to the full range that the image package expects.
+func (f *Frame) Image() (image.Image, error) {
+	if f.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
+	if !f.isVideo() {
+		return nil, errors.New("not a video frame")
+	}
+
+	if f._frame.HwFramesCtx != nil {
+		return nil, errors.New("hardware frames must be transferred to system memory first")
+	}
+
+	for i := 0; i < f.Planes(); i++ {
+		if f.Plane(i) == nil {
+			return nil, errors.New("frames with negative linesizes are not supported")
+		}
+	}
+
+	width, height := int(f._frame.Width), int(f._frame.Height)
+	rect := image.Rect(0, 0, width, height)
+	format := avutil.PixelFormat(f._frame.Format)
+
+	if ratio, ok := yCbCrSubsampleRatios[format]; ok {
+		img := image.NewYCbCr(rect, ratio)
+		chromaWidth, chromaHeight := img.CStride, len(img.Cb)/img.CStride
+
+		copyPlane(img.Y, img.YStride, f.Plane(0), int(f._frame.Linesize[0]), width, height)
+		copyPlane(img.Cb, img.CStride, f.Plane(1), int(f._frame.Linesize[1]), chromaWidth, chromaHeight)
+		copyPlane(img.Cr, img.CStride, f.Plane(2), int(f._frame.Linesize[2]), chromaWidth, chromaHeight)
+
+		// frames without a color range are assumed to be limited range, like
+		// libswscale does
+		if !fullRangePixelFormats[format] && avutil.ColorRange(f._frame.ColorRange) != avutil.ColorRangeJPEG {
+			mapSamples(img.Y, &lumaToFullRange)
+			mapSamples(img.Cb, &chromaToFullRange)
+			mapSamples(img.Cr, &chromaToFullRange)
+		}
+
+		return img, nil
+	}
+
+	switch format {
+	case avutil.PixelFormatGray8:
+		img := image.NewGray(rect)
+		copyPlane(img.Pix, img.Stride, f.Plane(0), int(f._frame.Linesize[0]), width, height)
+
+		return img, nil
+
+	case avutil.PixelFormatRGBA:
+		img := image.NewNRGBA(rect)
+		copyPlane(img.Pix, img.Stride, f.Plane(0), int(f._frame.Linesize[0]), width*4, height)
+
+		return img, nil
+
+	case avutil.PixelFormatRGB0:
+		img := image.NewRGBA(rect)
+		copyPlane(img.Pix, img.Stride, f.Plane(0), int(f._frame.Linesize[0]), width*4, height)
+
+		// the fourth byte of rgb0 is unused
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xff
+		}
+
+		return img, nil
+	}
+
+	return nil, errors.Errorf("unsupported pixel format: %s", format)
+}
+
+// FrameFromImage copies the pixels of img into a new frame. *image.YCbCr is
+// converted to the matching planar yuv format with full color range, as used
+// by the image package, *image.Gray to gray8 and any other image to rgba.
+func FrameFromImage(img image.Image) (*Frame, error) {
+	r := img.Bounds()
+	width, height := r.Dx(), r.Dy()
+
+	switch img := img.(type) {
+	case *image.YCbCr:
+		format, ok := yCbCrPixelFormats[img.SubsampleRatio]
+		if !ok {
+			break
+		}
+
+		// fall back to rgba if the sub image does not start on a chroma sample
+		hshift, vshift := format.ChromaSubsample()
+		if r.Min.X%(1<<hshift) != 0 || r.Min.Y%(1<<vshift) != 0 {
+			break
+		}
+
+		frame, err := NewVideoFrame(int32(width), int32(height), format)
+		if err != nil {
+			return nil, err
+		}
+
+		frame._frame.ColorRange = uint32(avutil.ColorRangeJPEG)
+
+		chromaWidth := (width + 1<<hshift - 1) >> hshift
+		chromaHeight := (height + 1<<vshift - 1) >> vshift
+
+		copyPlane(frame.Plane(0), int(frame._frame.Linesize[0]), img.Y[img.YOffset(r.Min.X, r.Min.Y):], img.YStride, width, height)
+		copyPlane(frame.Plane(1), int(frame._frame.Linesize[1]), img.Cb[img.COffset(r.Min.X, r.Min.Y):], img.CStride, chromaWidth, chromaHeight)
+		copyPlane(frame.Plane(2), int(frame._frame.Linesize[2]), img.Cr[img.COffset(r.Min.X, r.Min.Y):], img.CStride, chromaWidth, chromaHeight)
+
+		return frame, nil
+
+	case *image.Gray:
+		frame, err := NewVideoFrame(int32(width), int32(height), avutil.PixelFormatGray8)
+		if err != nil {
+			return nil, err
+		}
+
+		copyPlane(frame.Plane(0), int(frame._frame.Linesize[0]), img.Pix[img.PixOffset(r.Min.X, r.Min.Y):], img.Stride, width, height)
+
+		return frame, nil
+	}
+
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok {
+		nrgba = image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(nrgba, nrgba.Bounds(), img, r.Min, draw.Src)
+		r = nrgba.Bounds()
+	}
+
+	frame, err := NewVideoFrame(int32(width), int32(height), avutil.PixelFormatRGBA)
+	if err != nil {
+		return nil, err
+	}
+
+	copyPlane(frame.Plane(0), int(frame._frame.Linesize[0]), nrgba.Pix[nrgba.PixOffset(r.Min.X, r.Min.Y):], nrgba.Stride, width*4, height)
+
+	return frame, nil
+}
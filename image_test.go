@@ -0,0 +1,118 @@
+package av_test
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func TestImageRoundTrip(t *testing.T) {
+	ratios := map[string]image.YCbCrSubsampleRatio{
+		"420": image.YCbCrSubsampleRatio420,
+		"422": image.YCbCrSubsampleRatio422,
+		"444": image.YCbCrSubsampleRatio444,
+		"440": image.YCbCrSubsampleRatio440,
+		"411": image.YCbCrSubsampleRatio411,
+	}
+
+	for name, ratio := range ratios {
+		t.Run(name, func(t *testing.T) {
+			// odd sizes make sure that partial chroma samples are copied
+			src := image.NewYCbCr(image.Rect(0, 0, 13, 7), ratio)
+			for i := range src.Y {
+				src.Y[i] = byte(i)
+			}
+
+			for i := range src.Cb {
+				src.Cb[i] = byte(i * 3)
+				src.Cr[i] = byte(255 - i)
+			}
+
+			frame, err := av.FrameFromImage(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer frame.Free()
+
+			img, err := frame.Image()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dst, ok := img.(*image.YCbCr)
+			if !ok {
+				t.Fatalf("got %T, want *image.YCbCr", img)
+			}
+
+			if dst.SubsampleRatio != ratio {
+				t.Errorf("got ratio %s, want %s", dst.SubsampleRatio, ratio)
+			}
+
+			if !bytes.Equal(dst.Y, src.Y) || !bytes.Equal(dst.Cb, src.Cb) || !bytes.Equal(dst.Cr, src.Cr) {
+				t.Error("pixels changed after round trip")
+			}
+		})
+	}
+}
+
+func TestFrameFromImageRatio410(t *testing.T) {
+	frame, err := av.FrameFromImage(image.NewYCbCr(image.Rect(0, 0, 16, 16), image.YCbCrSubsampleRatio410))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer frame.Free()
+
+	// libav's yuv410p has a different chroma layout, so rgba is used instead
+	if format := avutil.PixelFormat(frame.Format); format != avutil.PixelFormatRGBA {
+		t.Errorf("got %s, want rgba", format)
+	}
+}
+
+func TestImageYUV410PUnsupported(t *testing.T) {
+	frame, err := av.NewVideoFrame(16, 15, avutil.PixelFormatYUV410P)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer frame.Free()
+
+	if _, err := frame.Image(); err == nil {
+		t.Error("expected yuv410p to be rejected")
+	}
+}
+
+func TestImageLimitedRange(t *testing.T) {
+	frame, err := av.NewVideoFrame(2, 2, avutil.PixelFormatYUV420P)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer frame.Free()
+
+	for i := 0; i < 2; i++ {
+		copy(frame.Plane(0)[i*int(frame.Linesize[0]):], []byte{16, 235})
+	}
+
+	frame.Plane(1)[0] = 16
+	frame.Plane(2)[0] = 240
+
+	img, err := frame.Image()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ycbcr := img.(*image.YCbCr)
+	if want := []byte{0, 255, 0, 255}; !bytes.Equal(ycbcr.Y, want) {
+		t.Errorf("got luma %v, want %v", ycbcr.Y, want)
+	}
+
+	// the chroma extremes are off by one after rounding
+	if ycbcr.Cb[0] > 1 || ycbcr.Cr[0] < 254 {
+		t.Errorf("got chroma %d, %d, want about 0, 255", ycbcr.Cb[0], ycbcr.Cr[0])
+	}
+}
@@ -1,10 +1,15 @@
 package av
 
 import (
-	"errors"
+	"context"
 	"io"
+	"math"
 	"runtime"
+	"runtime/cgo"
+	"time"
+	"unsafe"
 
+	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avformat"
 	"github.com/ssttevee/go-av/avutil"
 	"github.com/ssttevee/go-av/internal/common"
@@ -17,97 +22,209 @@ type InputFormatContext struct {
 }
 
 func finalizeInputFormatContext(ctx *InputFormatContext) {
-	ctx.finalizePinnedData()
-	// heap pointer may not be passed to cgo, so use a stack pointer instead :D
-	formatCtx := (*avformat.Context)(ctx._formatContext)
-	avformat.CloseInput(&formatCtx)
-	ctx._formatContext = formatCtx
+	if ctx.freed() {
+		return
+	}
+
+	untrackPointer(FormatContextObject, unsafe.Pointer(ctx._formatContext))
+	ctx.finalizePinnedData(func() {
+		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+		formatCtx := (*avformat.Context)(ctx._formatContext)
+		avformat.CloseInput(&formatCtx)
+		ctx._formatContext = formatCtx
+	})
 }
 
-func OpenInputFile(input string) (*InputFormatContext, error) {
-	var ctx *avformat.Context
-	if err := averror(avformat.OpenInput(&ctx, input, nil, nil)); err != nil {
-		return nil, err
+// Close closes the input and releases the context without waiting for the
+// garbage collector. It is safe to call Close more than once and using the
+// context afterwards returns ErrClosed.
+func (ctx *InputFormatContext) Close() error {
+	runtime.SetFinalizer(ctx, nil)
+	finalizeInputFormatContext(ctx)
+
+	// custom io contexts are not freed by avformat_close_input
+	if ctx.ioctx != nil {
+		ctx.ioctx.free()
+		ctx.ioctx = nil
 	}
 
-	ret := &InputFormatContext{
-		formatContext: formatContext{
-			_formatContext: ctx,
-		},
-	}
+	return nil
+}
 
-	runtime.SetFinalizer(ret, finalizeInputFormatContext)
+func OpenInputFile(input string, opts ...Option) (*InputFormatContext, error) {
+	return OpenInputFileContext(context.Background(), input, opts...)
+}
 
-	if err := ret.realError(averror(avformat.FindStreamInfo(ctx, nil))); err != nil {
+// OpenInputFileContext is like OpenInputFile, but opening the input is
+// aborted when ctx is done.
+func OpenInputFileContext(ctx context.Context, input string, opts ...Option) (*InputFormatContext, error) {
+	ret := newInputFormatContext()
+	if err := ret.open(ctx, input, opts); err != nil {
 		return nil, err
 	}
 
 	return ret, nil
 }
 
-func OpenInputReader(r io.Reader) (*InputFormatContext, error) {
-	ioctx := newIOContext(r, false)
+func OpenInputReader(r io.Reader, opts ...Option) (*InputFormatContext, error) {
+	return OpenInputReaderContext(context.Background(), r, opts...)
+}
 
-	ctx := avformat.NewContext()
-	if ctx == nil {
-		panic(avutil.ErrNoMem)
-	}
+// OpenInputReaderContext is like OpenInputReader, but opening the input is
+// aborted when ctx is done. Reads that are already blocked can only be
+// interrupted if r has a SetReadDeadline method, like net.Conn.
+func OpenInputReaderContext(ctx context.Context, r io.Reader, opts ...Option) (*InputFormatContext, error) {
+	ioctx := newIOContext(r, false)
 
-	ctx.Opaque = nil
-	ctx.Pb = ioctx._ioContext
+	ret := newInputFormatContext()
+	ret.Pb = ioctx._ioContext
+	ret.ioctx = ioctx
 
-	ret := &InputFormatContext{
-		formatContext: formatContext{
-			_formatContext: ctx,
-		},
-		ioctx: ioctx,
-	}
+	unwrapPinnedFile(ioctx.Opaque).owner = ret.pinnedData()
 
-	if err := ret.realError(averror(avformat.OpenInput(&ctx, "", nil, nil))); err != nil {
+	if err := ret.open(ctx, "", opts); err != nil {
 		return nil, err
 	}
 
-	runtime.SetFinalizer(ret, finalizeInputFormatContext)
+	return ret, nil
+}
+
+func OpenInputWithOpener(opener Opener, url string, opts ...Option) (*InputFormatContext, error) {
+	return OpenInputWithOpenerContext(context.Background(), opener, url, opts...)
+}
 
-	if err := ret.realError(averror(avformat.FindStreamInfo(ctx, nil))); err != nil {
+// OpenInputWithOpenerContext is like OpenInputWithOpener, but opening the
+// input is aborted when ctx is done.
+func OpenInputWithOpenerContext(ctx context.Context, opener Opener, url string, opts ...Option) (*InputFormatContext, error) {
+	ret := newInputFormatContext()
+	ret.SetOpener(opener)
+
+	if err := ret.open(ctx, url, opts); err != nil {
 		return nil, err
 	}
 
 	return ret, nil
 }
 
-func OpenInputWithOpener(opener Opener, url string) (*InputFormatContext, error) {
+func newInputFormatContext() *InputFormatContext {
 	ctx := avformat.NewContext()
 	if ctx == nil {
 		panic(avutil.ErrNoMem)
 	}
 
-	ret := &InputFormatContext{
+	trackPointer(FormatContextObject, unsafe.Pointer(ctx))
+
+	return &InputFormatContext{
 		formatContext: formatContext{
 			_formatContext: ctx,
 		},
 	}
+}
 
-	runtime.SetFinalizer(ret, finalizeInputFormatContext)
+func (ctx *InputFormatContext) open(c context.Context, url string, opts []Option) error {
+	dict, err := resolveOptionsDict(opts...)
+	if err != nil {
+		ctx.freeUnopened()
+		return err
+	}
 
-	ret.SetOpener(opener)
+	defer avutil.FreeDict(&dict)
 
-	if err := ret.realError(averror(avformat.OpenInput(&ctx, url, nil, nil))); err != nil {
-		return nil, err
+	// the logger is popped first so that it is released on any failure
+	logger, err := popLogger(&dict)
+	if err != nil {
+		ctx.freeUnopened()
+		return err
 	}
 
-	if err := ret.realError(averror(avformat.FindStreamInfo(ctx, nil))); err != nil {
-		return nil, err
+	if logger != nil {
+		ctx.SetLogger(logger)
 	}
 
-	return ret, nil
+	format, err := popForcedInputFormat(&dict)
+	if err != nil {
+		ctx.freeUnopened()
+		return err
+	}
+
+	pb := ctx.Pb
+	data := ctx.pinnedData()
+	handle := cgo.Handle(ctx.Opaque)
+	ptr := unsafe.Pointer(ctx._formatContext)
+
+	// the messages that explain a failure are attached to the returned error
+	capture := captureLogs(uintptr(ptr))
+
+	// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+	formatCtx := (*avformat.Context)(ctx._formatContext)
+	err = ctx.withContext(c, func() error {
+		return averror(avformat.OpenInput(&formatCtx, url, format, &dict))
+	})
+	ctx._formatContext = formatCtx
+
+	if err != nil {
+		// the context is freed by avformat_open_input on failure
+		untrackPointer(FormatContextObject, ptr)
+		setContextLogHandler(uintptr(ptr), nil)
+		deleteHandle(handle)
+		return capture.stop(realFormatError(err, pb, data))
+	}
+
+	runtime.SetFinalizer(ctx, finalizeInputFormatContext)
+
+	// the input is not returned on failure, so close it right away
+	if err := unconsumedOptionsError(dict); err != nil {
+		capture.stop(nil)
+		ctx.Close()
+		return err
+	}
+
+	if err := capture.stop(ctx.realError(ctx.withContext(c, func() error {
+		return averror(avformat.FindStreamInfo(ctx._formatContext, nil))
+	}))); err != nil {
+		ctx.Close()
+		return err
+	}
+
+	return nil
+}
+
+// freeUnopened frees a context that failed before avformat_open_input was
+// called.
+func (ctx *InputFormatContext) freeUnopened() {
+	untrackPointer(FormatContextObject, unsafe.Pointer(ctx._formatContext))
+	ctx.finalizePinnedData(func() {
+		avformat.FreeContext(ctx._formatContext)
+		ctx._formatContext = nil
+	})
+}
+
+// InputFormat returns the format of the input.
+func (ctx *InputFormatContext) InputFormat() *InputFormat {
+	return &InputFormat{_inputFormat: ctx.Iformat}
 }
 
 func (ctx *InputFormatContext) ReadPacketReuse(packet *Packet) error {
+	if ctx.freed() || packet.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	return ctx.realError(averror(avformat.ReadFrame(ctx._formatContext, packet.prepare())))
 }
 
+// ReadPacketReuseContext is like ReadPacketReuse, but the read is aborted when
+// ctx is done.
+func (ctx *InputFormatContext) ReadPacketReuseContext(c context.Context, packet *Packet) error {
+	return ctx.withContext(c, func() error {
+		return ctx.ReadPacketReuse(packet)
+	})
+}
+
 func (ctx *InputFormatContext) ReadPacket() (*Packet, error) {
+	if ctx.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
 	packet := NewPacket()
 	if err := ctx.realError(averror(avformat.ReadFrame(ctx._formatContext, packet._packet))); err != nil {
 		return nil, err
@@ -116,18 +233,105 @@ func (ctx *InputFormatContext) ReadPacket() (*Packet, error) {
 	return packet, nil
 }
 
+// ReadPacketContext is like ReadPacket, but the read is aborted when ctx is
+// done.
+func (ctx *InputFormatContext) ReadPacketContext(c context.Context) (*Packet, error) {
+	var packet *Packet
+	err := ctx.withContext(c, func() (err error) {
+		packet, err = ctx.ReadPacket()
+		return
+	})
+
+	return packet, err
+}
+
+// ReadPacketWithPool is like ReadPacket, but the packet is taken from pool.
+func (ctx *InputFormatContext) ReadPacketWithPool(pool *PacketPool) (*Packet, error) {
+	packet := pool.Get()
+	if err := ctx.ReadPacketReuse(packet); err != nil {
+		pool.Put(packet)
+		return nil, err
+	}
+
+	return packet, nil
+}
+
+// ReadPacketWithPoolContext is like ReadPacketWithPool, but the read is
+// aborted when ctx is done.
+func (ctx *InputFormatContext) ReadPacketWithPoolContext(c context.Context, pool *PacketPool) (*Packet, error) {
+	var packet *Packet
+	err := ctx.withContext(c, func() (err error) {
+		packet, err = ctx.ReadPacketWithPool(pool)
+		return
+	})
+
+	return packet, err
+}
+
 func (ctx *InputFormatContext) SeekFile(streamIndex int32, minTimestamp, timestamp, maxTimestamp int64, flags int32) error {
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	return ctx.realError(averror(avformat.SeekFile(ctx._formatContext, streamIndex, minTimestamp, timestamp, maxTimestamp, flags)))
 }
 
+// SeekTo seeks to the closest keyframe at or before the given position,
+// relative to the start of the input.
+func (ctx *InputFormatContext) SeekTo(d time.Duration) error {
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	ts := ctx.timestamp(d, avutil.Rat(1, avutil.TimeBase))
+	return ctx.SeekFile(-1, math.MinInt64, ts, ts, 0)
+}
+
+// timestamp converts a position in the timeline of the input, which starts at
+// the start time of the input, to a timestamp in the given time base.
+func (ctx *InputFormatContext) timestamp(d time.Duration, timeBase avutil.Rational) int64 {
+	ts := durationToTimestamp(d, avutil.Rat(1, avutil.TimeBase))
+	if ctx.StartTime != avutil.NoPtsValue {
+		ts += ctx.StartTime
+	}
+
+	return avutil.RescaleQ(ts, avutil.Rat(1, avutil.TimeBase), timeBase)
+}
+
+// SeekStreamTo is like SeekTo, but the position is in the timeline of the
+// given stream.
+func (ctx *InputFormatContext) SeekStreamTo(streamIndex int32, d time.Duration) error {
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	ts := streamTimestamp(ctx.Stream(int(streamIndex)), d)
+	return ctx.SeekFile(streamIndex, math.MinInt64, ts, ts, 0)
+}
+
+func streamTimestamp(stream *Stream, d time.Duration) int64 {
+	ts := durationToTimestamp(d, stream.TimeBase)
+	if stream.StartTime != avutil.NoPtsValue {
+		ts += stream.StartTime
+	}
+
+	return ts
+}
+
 func (ctx *InputFormatContext) realError(err error) error {
+	return realFormatError(err, ctx.Pb, ctx.pinnedData())
+}
+
+func realFormatError(err error, pb *avformat.IOContext, data *pinnedFormatContextData) error {
 	if averr, ok := errors.Unwrap(err).(avutil.Error); ok {
 		switch int(averr) {
 		case common.IOError:
-			return unwrapPinnedFile(ctx.Pb.Opaque).err
+			if pb != nil {
+				return unwrapPinnedFile(pb.Opaque).err
+			}
 
 		case common.FormatError:
-			return ctx.pinnedData().err
+			return data.err
 		}
 	}
 
@@ -0,0 +1,150 @@
+package av
+
+import (
+	"reflect"
+	"strings"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avformat"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+type InputFormatNotFoundError string
+
+func (e InputFormatNotFoundError) Error() string {
+	return "input format not found: " + string(e)
+}
+
+type _inputFormat = avformat.InputFormat
+
+type InputFormat struct {
+	*_inputFormat
+}
+
+// FindInputFormat returns the demuxer with the given short name, like "h264",
+// "s16le" or "mjpeg".
+func FindInputFormat(name string) (*InputFormat, error) {
+	format := avformat.FindInputFormat(name)
+	if format == nil {
+		return nil, errors.WithStack(InputFormatNotFoundError(name))
+	}
+
+	return &InputFormat{_inputFormat: format}, nil
+}
+
+// Name returns the comma separated short names of the format.
+func (f *InputFormat) Name() string {
+	return f._inputFormat.Name.String()
+}
+
+func (f *InputFormat) LongName() string {
+	return f._inputFormat.LongName.String()
+}
+
+// Extensions returns the comma separated file extensions of the format.
+func (f *InputFormat) Extensions() string {
+	return f._inputFormat.Extensions.String()
+}
+
+// MimeType returns the comma separated mime types of the format.
+func (f *InputFormat) MimeType() string {
+	return f._inputFormat.MimeType.String()
+}
+
+// forceInputFormatKey is the key of the option that carries the name of the
+// forced input format. It is removed before the options are passed to libav.
+const forceInputFormatKey = "goav_input_format"
+
+// ForceInputFormat returns an option that makes OpenInput* skip probing and
+// use the given format instead.
+func ForceInputFormat(format *InputFormat) Option {
+	// the first short name is enough to find the format again
+	return StringOption(forceInputFormatKey, strings.SplitN(format.Name(), ",", 2)[0])
+}
+
+// popForcedInputFormat removes the forced input format option from dict and
+// returns the format, or nil if there is none.
+func popForcedInputFormat(dict **avutil.Dictionary) (*avformat.InputFormat, error) {
+	entry := avutil.GetDict(*dict, forceInputFormatKey, nil, avutil.DictMatchCase)
+	if entry == nil {
+		return nil, nil
+	}
+
+	format, err := FindInputFormat(entry.Value.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// a null value removes the entry
+	if err := averror(avutil.SetDict(dict, forceInputFormatKey, "", 0)); err != nil {
+		return nil, err
+	}
+
+	return format._inputFormat, nil
+}
+
+// ProbeFormat guesses the format of data, which should be the beginning of
+// the input. It returns the short names of the detected format and a score
+// from 1 to 100, or an empty name if no format was detected.
+func ProbeFormat(data []byte) (string, int) {
+	// the buffer must be followed by zeroed padding
+	size := len(data) + avformat.ProbePaddingSize
+	buf := avutil.Malloc(uint64(size))
+	if buf == nil {
+		panic(avutil.ErrNoMem)
+	}
+
+	defer avutil.Free(buf)
+
+	cbuf := *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
+		Data: uintptr(buf),
+		Len:  size,
+		Cap:  size,
+	}))
+
+	copy(cbuf, data)
+	for i := len(data); i < size; i++ {
+		cbuf[i] = 0
+	}
+
+	pd := avformat.ProbeData{
+		Buf:     (*byte)(buf),
+		BufSize: int32(len(data)),
+	}
+
+	var score int32
+	format := avformat.ProbeInputFormat(&pd, 1, &score)
+	if format == nil {
+		return "", 0
+	}
+
+	return format.Name.String(), int(score)
+}
+
+// InputFormatIterator iterates over the demuxers in libavformat. The zero
+// value is ready to use.
+type InputFormatIterator struct {
+	opaque unsafe.Pointer
+}
+
+// Next returns the next demuxer, or nil once all demuxers have been returned.
+func (it *InputFormatIterator) Next() *InputFormat {
+	format := avformat.IterateDemuxers(&it.opaque)
+	if format == nil {
+		return nil
+	}
+
+	return &InputFormat{_inputFormat: format}
+}
+
+// InputFormats returns all demuxers in libavformat.
+func InputFormats() []*InputFormat {
+	var formats []*InputFormat
+	var it InputFormatIterator
+	for format := it.Next(); format != nil; format = it.Next() {
+		formats = append(formats, format)
+	}
+
+	return formats
+}
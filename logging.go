@@ -0,0 +1,275 @@
+package av
+
+import (
+	"log/slog"
+	"strconv"
+	"sync"
+	"unsafe"
+
+	"github.com/ssttevee/go-av/avcodec"
+	"github.com/ssttevee/go-av/avfilter"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func init() {
+	avutil.SetLogHandlerResolver(resolveLogHandler)
+}
+
+// contextLogHandlers maps contexts to the handlers of their log messages.
+// Codec contexts are keyed by their pinned data handle, since libavcodec logs
+// with copies of the context when decoding or encoding with frame threads.
+var contextLogHandlers struct {
+	sync.RWMutex
+	m map[uintptr]slog.Handler
+}
+
+func setContextLogHandler(key uintptr, h slog.Handler) {
+	contextLogHandlers.Lock()
+	defer contextLogHandlers.Unlock()
+
+	if h == nil {
+		delete(contextLogHandlers.m, key)
+		return
+	}
+
+	if contextLogHandlers.m == nil {
+		contextLogHandlers.m = map[uintptr]slog.Handler{}
+	}
+
+	contextLogHandlers.m[key] = h
+}
+
+func contextLogHandler(key uintptr) slog.Handler {
+	contextLogHandlers.RLock()
+	defer contextLogHandlers.RUnlock()
+
+	return contextLogHandlers.m[key]
+}
+
+func loggerHandler(l *slog.Logger) slog.Handler {
+	if l == nil {
+		return nil
+	}
+
+	return l.Handler()
+}
+
+// resolveLogHandler returns the handler of the context that logged a message,
+// following the parents of contexts that were not created by this package.
+func resolveLogHandler(ptr unsafe.Pointer) slog.Handler {
+	var h slog.Handler
+	walkLogContexts(ptr, func(key uintptr) bool {
+		h = contextLogHandler(key)
+		return h != nil
+	})
+
+	return h
+}
+
+// walkLogContexts calls f with the key of the context that logged a message
+// and then the keys of its parents until f returns true.
+func walkLogContexts(ptr unsafe.Pointer, f func(key uintptr) bool) {
+	for ptr != nil {
+		class := *(**avutil.Class)(ptr)
+		if class == nil {
+			return
+		}
+
+		var key uintptr
+		switch class.ClassName.String() {
+		case "AVCodecContext":
+			key = uintptr((*avcodec.Context)(ptr).Opaque)
+
+		case "AVFilter":
+			key = uintptr(unsafe.Pointer((*avfilter.Context)(ptr).Graph))
+
+		default:
+			key = uintptr(ptr)
+		}
+
+		if f(key) {
+			return
+		}
+
+		if class.ParentLogContextOffset == 0 {
+			return
+		}
+
+		ptr = *(*unsafe.Pointer)(unsafe.Add(ptr, class.ParentLogContextOffset))
+	}
+}
+
+// logCapture collects the warnings and errors that are logged by a context
+// while a call to libav is running.
+type logCapture struct {
+	key uintptr
+
+	mu       sync.Mutex
+	messages []LogMessage
+}
+
+var logCaptures struct {
+	sync.RWMutex
+	m map[uintptr]*logCapture
+}
+
+// captureLogs starts collecting the messages of the context with the given
+// key. The log observer is only set while there are captures, so that other
+// messages do not have to be copied.
+func captureLogs(key uintptr) *logCapture {
+	c := &logCapture{key: key}
+
+	logCaptures.Lock()
+	defer logCaptures.Unlock()
+
+	if logCaptures.m == nil {
+		logCaptures.m = map[uintptr]*logCapture{}
+	}
+
+	logCaptures.m[key] = c
+	if len(logCaptures.m) == 1 {
+		avutil.SetLogObserver(observeLog)
+	}
+
+	return c
+}
+
+// stop stops collecting messages and attaches the collected messages to err,
+// if it is not nil.
+func (c *logCapture) stop(err error) error {
+	logCaptures.Lock()
+	if logCaptures.m[c.key] == c {
+		delete(logCaptures.m, c.key)
+		if len(logCaptures.m) == 0 {
+			avutil.SetLogObserver(nil)
+		}
+	}
+	logCaptures.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil || len(c.messages) == 0 {
+		return err
+	}
+
+	return &LogError{
+		Err:      err,
+		Messages: c.messages,
+	}
+}
+
+func observeLog(ptr unsafe.Pointer, level avutil.VerbosityLevel, item, message string) {
+	if level > avutil.Warning {
+		return
+	}
+
+	logCaptures.RLock()
+	defer logCaptures.RUnlock()
+
+	walkLogContexts(ptr, func(key uintptr) bool {
+		c := logCaptures.m[key]
+		if c == nil {
+			return false
+		}
+
+		c.mu.Lock()
+		c.messages = append(c.messages, LogMessage{
+			Level:   level,
+			Item:    item,
+			Message: message,
+		})
+		c.mu.Unlock()
+
+		return true
+	})
+}
+
+// loggerOptionKey is the key of the option that carries the id of the logger
+// given to WithLogger. It is removed before the options are passed to libav.
+const loggerOptionKey = "goav_logger"
+
+var optionLoggers struct {
+	sync.Mutex
+	next uint64
+	m    map[string]*slog.Logger
+}
+
+// WithLogger returns an option that routes the log messages of a codec, input
+// or output context to l, including the messages that are logged while the
+// context is opened or the header is written.
+func WithLogger(l *slog.Logger) Option {
+	return func(pm **avutil.Dictionary) error {
+		optionLoggers.Lock()
+		// a previous logger option is replaced
+		if entry := avutil.GetDict(*pm, loggerOptionKey, nil, avutil.DictMatchCase); entry != nil {
+			delete(optionLoggers.m, entry.Value.String())
+		}
+
+		optionLoggers.next++
+		id := strconv.FormatUint(optionLoggers.next, 10)
+		if optionLoggers.m == nil {
+			optionLoggers.m = map[string]*slog.Logger{}
+		}
+
+		optionLoggers.m[id] = l
+		optionLoggers.Unlock()
+
+		return averror(avutil.SetDict(pm, loggerOptionKey, id, 0))
+	}
+}
+
+// popLogger removes the logger option from dict and returns the logger, or
+// nil if there is none.
+func popLogger(dict **avutil.Dictionary) (*slog.Logger, error) {
+	entry := avutil.GetDict(*dict, loggerOptionKey, nil, avutil.DictMatchCase)
+	if entry == nil {
+		return nil, nil
+	}
+
+	id := entry.Value.String()
+
+	optionLoggers.Lock()
+	l := optionLoggers.m[id]
+	delete(optionLoggers.m, id)
+	optionLoggers.Unlock()
+
+	// a null value removes the entry
+	if err := averror(avutil.SetDict(dict, loggerOptionKey, "", 0)); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// SetLogger routes the log messages of the codec to l instead of the handler
+// set with avutil.SetLogHandler. A nil logger removes the routing.
+func (ctx *codecContext) SetLogger(l *slog.Logger) {
+	if ctx.freed() {
+		return
+	}
+
+	ctx.pinnedData()
+	setContextLogHandler(uintptr(ctx.Opaque), loggerHandler(l))
+}
+
+// SetLogger routes the log messages of the format to l instead of the handler
+// set with avutil.SetLogHandler. A nil logger removes the routing.
+func (ctx *formatContext) SetLogger(l *slog.Logger) {
+	if ctx.freed() {
+		return
+	}
+
+	setContextLogHandler(uintptr(unsafe.Pointer(ctx._formatContext)), loggerHandler(l))
+}
+
+// SetLogger routes the log messages of the graph and its filters to l instead
+// of the handler set with avutil.SetLogHandler. A nil logger removes the
+// routing.
+func (g *FilterGraph) SetLogger(l *slog.Logger) {
+	if g.freed() {
+		return
+	}
+
+	setContextLogHandler(uintptr(unsafe.Pointer(g._filterGraph)), loggerHandler(l))
+}
@@ -0,0 +1,77 @@
+package av_test
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// sendInvalidPacket makes the h264 decoder of the test media log errors.
+func sendInvalidPacket(t *testing.T, setup func(decoder *av.DecoderContext)) {
+	t.Helper()
+
+	stream := openTestMedia(t).Stream(0)
+
+	codec, err := av.FindDecoderCodecByID(stream.Codecpar().CodecID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder, err := av.NewDecoderContext(codec, stream.Codecpar())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer decoder.Free()
+
+	setup(decoder)
+
+	packet, err := av.NewPacketFromBytes([]byte{0, 0, 0, 0xff, 0x65, 1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer packet.Free()
+
+	// the error is expected, only the logs are checked
+	_ = decoder.SendPacket(packet)
+}
+
+func TestSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	sendInvalidPacket(t, func(decoder *av.DecoderContext) {
+		decoder.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	})
+
+	if buf.Len() == 0 {
+		t.Fatal("nothing was logged")
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if !strings.Contains(line, "item=h264") {
+			t.Errorf("got %q, want the h264 item", line)
+		}
+
+		// the prefix is replaced by the item attribute
+		if strings.Contains(line, "@ 0x") {
+			t.Errorf("got %q, want the message without the prefix", line)
+		}
+	}
+}
+
+func TestLegacyLogger(t *testing.T) {
+	var buf bytes.Buffer
+	avutil.Logger = log.New(&buf, "", 0)
+	defer func() { avutil.Logger = nil }()
+
+	sendInvalidPacket(t, func(decoder *av.DecoderContext) {})
+
+	if !strings.HasPrefix(buf.String(), "[h264 @ 0x") {
+		t.Errorf("got %q, want lines with the item prefix", buf.String())
+	}
+}
@@ -0,0 +1,62 @@
+package av_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+// testMediaFile is an h264 and aac mp4 that is expected in the working
+// directory, see TestOpenInputReader for where to download it.
+const testMediaFile = "big_buck_bunny_720p_surround.mp4"
+
+func openTestMedia(t testing.TB) *av.InputFormatContext {
+	t.Helper()
+
+	input, err := av.OpenInputWithOpener(av.FileOpener, testMediaFile)
+	if err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	t.Cleanup(func() { input.Close() })
+
+	return input
+}
+
+// testClip returns the first d of the test media remuxed to matroska, which
+// keeps tests that decode the whole input fast.
+func testClip(t testing.TB, d time.Duration) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	output, err := av.NewWriterOutputContext("matroska", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the output is closed by Remux unless it fails
+	defer output.Free()
+
+	if err := av.Remux(context.Background(), openTestMedia(t), output, av.RemuxOptions{End: d}); err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	return buf.Bytes()
+}
+
+func openClip(t testing.TB, clip []byte) *av.InputFormatContext {
+	t.Helper()
+
+	input, err := av.OpenInputReader(bytes.NewReader(clip))
+	if err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	t.Cleanup(func() { input.Close() })
+
+	return input
+}
@@ -0,0 +1,128 @@
+package av
+
+import (
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// dictToMap copies the entries of dict into a map. Later entries overwrite
+// earlier entries with the same key.
+func dictToMap(dict *avutil.Dictionary) map[string]string {
+	entries := avutil.DictEntries(dict)
+
+	m := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		m[entry.Key.String()] = entry.Value.String()
+	}
+
+	return m
+}
+
+// replaceDict replaces all entries of dict with the entries of m. Entries
+// with an empty value are left out.
+func replaceDict(dict **avutil.Dictionary, m map[string]string) error {
+	avutil.FreeDict(dict)
+
+	for key, value := range m {
+		if err := averror(avutil.SetDict(dict, key, value, 0)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns the tags of the container, like title or artist.
+func (ctx *formatContext) Metadata() map[string]string {
+	return dictToMap(ctx._formatContext.Metadata)
+}
+
+// MetadataValue returns the value of a single container tag.
+func (ctx *formatContext) MetadataValue(key string) (string, bool) {
+	return getDictValue(ctx._formatContext.Metadata, key)
+}
+
+// SetMetadata replaces the tags of the container. It must be called before
+// the header is written.
+func (ctx *OutputFormatContext) SetMetadata(m map[string]string) error {
+	return replaceDict(&ctx._formatContext.Metadata, m)
+}
+
+// SetMetadataValue sets a single container tag, or removes it if value is
+// empty. It must be called before the header is written.
+func (ctx *OutputFormatContext) SetMetadataValue(key, value string) error {
+	return averror(avutil.SetDict(&ctx._formatContext.Metadata, key, value, 0))
+}
+
+// Metadata returns the tags of the stream, like language.
+func (s *Stream) Metadata() map[string]string {
+	return dictToMap(s._stream.Metadata)
+}
+
+// MetadataValue returns the value of a single stream tag.
+func (s *Stream) MetadataValue(key string) (string, bool) {
+	return getDictValue(s._stream.Metadata, key)
+}
+
+// SetMetadata replaces the tags of the stream. For output streams, it must be
+// called before the header is written.
+func (s *Stream) SetMetadata(m map[string]string) error {
+	return replaceDict(&s._stream.Metadata, m)
+}
+
+// SetMetadataValue sets a single stream tag, or removes it if value is empty.
+// For output streams, it must be called before the header is written.
+func (s *Stream) SetMetadataValue(key, value string) error {
+	return averror(avutil.SetDict(&s._stream.Metadata, key, value, 0))
+}
+
+// Metadata returns the metadata attached to the frame by decoders and
+// filters.
+func (f *Frame) Metadata() map[string]string {
+	if f.freed() {
+		return nil
+	}
+
+	return dictToMap(f._frame.Metadata)
+}
+
+// MetadataValue returns the value of a single frame metadata entry.
+func (f *Frame) MetadataValue(key string) (string, bool) {
+	if f.freed() {
+		return "", false
+	}
+
+	return getDictValue(f._frame.Metadata, key)
+}
+
+// SetMetadata replaces the metadata of the frame.
+func (f *Frame) SetMetadata(m map[string]string) error {
+	if f.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	return replaceDict(&f._frame.Metadata, m)
+}
+
+// SetMetadataValue sets a single frame metadata entry, or removes it if value
+// is empty.
+func (f *Frame) SetMetadataValue(key, value string) error {
+	if f.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	return averror(avutil.SetDict(&f._frame.Metadata, key, value, 0))
+}
+
+func getDictValue(dict *avutil.Dictionary, key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+
+	entry := avutil.GetDict(dict, key, nil, 0)
+	if entry == nil {
+		return "", false
+	}
+
+	return entry.Value.String(), true
+}
@@ -0,0 +1,135 @@
+package av_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+// muxClip copies the video of a short clip of the test media to a new
+// matroska output, which is set up by setup before the header is written, and
+// opens the result.
+func muxClip(t *testing.T, setup func(output *av.OutputFormatContext, stream *av.Stream)) *av.InputFormatContext {
+	t.Helper()
+
+	input := openClip(t, testClip(t, 2*time.Second))
+	in := input.Stream(0)
+
+	var buf bytes.Buffer
+	output, err := av.NewWriterOutputContext("matroska", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer output.Free()
+
+	out := output.NewStream(nil)
+	out.SetCodecpar(in.Codecpar())
+	out.Codecpar().CodecTag = 0
+	out.TimeBase = in.TimeBase
+
+	setup(output, out)
+
+	if err := output.WriteHeader(); err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	for {
+		packet, err := input.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if packet.StreamIndex == 0 {
+			packet.Rescale(in.TimeBase, out.TimeBase)
+			if err := output.WritePacket(packet); err != nil {
+				t.Fatal(fmterrors.FormatString(err))
+			}
+		}
+
+		packet.Free()
+	}
+
+	if err := output.Close(); err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	return openClip(t, buf.Bytes())
+}
+
+func TestOutputMetadata(t *testing.T) {
+	result := muxClip(t, func(output *av.OutputFormatContext, stream *av.Stream) {
+		if err := output.SetMetadata(map[string]string{"title": "Big Buck Bunny", "artist": "Blender Foundation", "comment": ""}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := output.SetMetadataValue("artist", ""); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := stream.SetMetadataValue("language", "eng"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if title, _ := result.MetadataValue("title"); title != "Big Buck Bunny" {
+		t.Errorf("got title %q, want \"Big Buck Bunny\"", title)
+	}
+
+	// empty values remove tags
+	for _, key := range []string{"artist", "comment"} {
+		if value, ok := result.MetadataValue(key); ok {
+			t.Errorf("got %s %q, want none", key, value)
+		}
+	}
+
+	if language, _ := result.Stream(0).MetadataValue("language"); language != "eng" {
+		t.Errorf("got language %q, want \"eng\"", language)
+	}
+
+	if _, ok := result.Metadata()["title"]; !ok {
+		t.Errorf("got %v, want a title", result.Metadata())
+	}
+}
+
+func TestFrameMetadata(t *testing.T) {
+	frame, err := av.NewVideoFrame(16, 16, avutil.PixelFormatYUV420P)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer frame.Free()
+
+	if err := frame.SetMetadata(map[string]string{"lavfi.scene_score": "0.5", "key": "value"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := frame.SetMetadataValue("key", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := map[string]string{"lavfi.scene_score": "0.5"}; !reflect.DeepEqual(frame.Metadata(), want) {
+		t.Errorf("got %v, want %v", frame.Metadata(), want)
+	}
+
+	// the metadata is carried by clones
+	clone, err := frame.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer clone.Free()
+
+	if score, ok := clone.MetadataValue("lavfi.scene_score"); !ok || score != "0.5" {
+		t.Errorf("got score %q of the clone, want 0.5", score)
+	}
+}
@@ -2,10 +2,17 @@ package av
 
 import (
 	"fmt"
+	"image/color"
+	"math"
+	"reflect"
 	"runtime"
+	"strings"
+	"time"
 	"unsafe"
 
+	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-av/internal/common"
 )
 
 type Option func(**avutil.Dictionary) error
@@ -16,10 +23,54 @@ func StringOption(name, value string) Option {
 	}
 }
 
+// UnconsumedOptionsError is returned when some of the given options were not
+// recognized by libav, which usually means their names are misspelled.
+type UnconsumedOptionsError []string
+
+func (e UnconsumedOptionsError) Error() string {
+	return "unconsumed options: " + strings.Join(e, ", ")
+}
+
+// unconsumedOptionsError returns an UnconsumedOptionsError for the options
+// left in dict, or nil if there are none.
+func unconsumedOptionsError(dict *avutil.Dictionary) error {
+	entries := avutil.DictEntries(dict)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Key.String()
+	}
+
+	return errors.WithStack(UnconsumedOptionsError(names))
+}
+
 func resolveOptionsDict(opts ...Option) (*avutil.Dictionary, error) {
 	var dict *avutil.Dictionary
 	for _, opt := range opts {
 		if err := opt(&dict); err != nil {
+			// the logger would never be popped otherwise
+			popLogger(&dict)
+			avutil.FreeDict(&dict)
+			return nil, err
+		}
+	}
+
+	return dict, nil
+}
+
+// ImageSize is the value of options of type avutil.OptionTypeImageSize.
+type ImageSize struct {
+	Width  int32
+	Height int32
+}
+
+func newOptionDict(m map[string]string) (*avutil.Dictionary, error) {
+	var dict *avutil.Dictionary
+	for k, v := range m {
+		if err := averror(avutil.SetDict(&dict, k, v, 0)); err != nil {
 			avutil.FreeDict(&dict)
 			return nil, err
 		}
@@ -33,30 +84,102 @@ func setOption(ptr unsafe.Pointer, name string, value interface{}, searchFlags i
 	case string:
 		return averror(avutil.SetOpt(ptr, name, v, searchFlags))
 
+	case bool:
+		var i int64
+		if v {
+			i = 1
+		}
+
+		return averror(avutil.SetOptInt(ptr, name, i, searchFlags))
+
 	case int:
 		return averror(avutil.SetOptInt(ptr, name, int64(v), searchFlags))
 
+	case int32:
+		return averror(avutil.SetOptInt(ptr, name, int64(v), searchFlags))
+
 	case int64:
 		return averror(avutil.SetOptInt(ptr, name, v, searchFlags))
 
+	case uint64:
+		// also used for channel layouts
+		return averror(avutil.SetOptInt(ptr, name, int64(v), searchFlags))
+
+	case float32:
+		return averror(avutil.SetOptDouble(ptr, name, float64(v), searchFlags))
+
 	case float64:
 		return averror(avutil.SetOptDouble(ptr, name, v, searchFlags))
 
+	case time.Duration:
+		return averror(avutil.SetOptInt(ptr, name, v.Microseconds(), searchFlags))
+
 	case avutil.Rational:
 		return averror(avutil.SetOptRational(ptr, name, v, searchFlags))
 
 	case avutil.PixelFormat:
 		return averror(avutil.SetOptPixelFormat(ptr, name, v, searchFlags))
 
+	case avutil.SampleFormat:
+		return averror(avutil.SetOptSampleFormat(ptr, name, v, searchFlags))
+
+	case ImageSize:
+		return averror(avutil.SetOptImageSize(ptr, name, v.Width, v.Height, searchFlags))
+
+	case color.Color:
+		c := color.NRGBAModel.Convert(v).(color.NRGBA)
+		return averror(avutil.SetOpt(ptr, name, fmt.Sprintf("0x%02x%02x%02x%02x", c.R, c.G, c.B, c.A), searchFlags))
+
+	case map[string]string:
+		dict, err := newOptionDict(v)
+		if err != nil {
+			return err
+		}
+
+		defer avutil.FreeDict(&dict)
+
+		return averror(avutil.SetOptDict(ptr, name, dict, searchFlags))
+
 	case []byte:
 		defer runtime.KeepAlive(v)
 
-		return averror(avutil.SetOptBin(ptr, name, &v[0], int32(len(v)), searchFlags))
+		var data *uint8
+		if len(v) > 0 {
+			data = &v[0]
+		}
+
+		return averror(avutil.SetOptBin(ptr, name, data, int32(len(v)), searchFlags))
 	}
 
 	panic(fmt.Sprintf("unexpected option value type: %T", value))
 }
 
+// optionIntValue converts the integer representation of an option value to
+// the type that is returned by getOption.
+func optionIntValue(t avutil.OptionType, v int64) interface{} {
+	switch t {
+	case avutil.OptionTypeBool:
+		return v != 0
+
+	case avutil.OptionTypeFlags, avutil.OptionTypeInt:
+		return int(v)
+
+	case avutil.OptionTypeUint64, avutil.OptionTypeChannelLayout:
+		return uint64(v)
+
+	case avutil.OptionTypeDuration:
+		return time.Duration(v) * time.Microsecond
+
+	case avutil.OptionTypePixelFormat:
+		return avutil.PixelFormat(v)
+
+	case avutil.OptionTypeSampleFormat:
+		return avutil.SampleFormat(v)
+	}
+
+	return v
+}
+
 func getOption(ptr unsafe.Pointer, name string, searchFlags int32) (interface{}, error) {
 	var target unsafe.Pointer
 	opt := avutil.FindOpt(ptr, name, "", 0, searchFlags, &target)
@@ -66,27 +189,223 @@ func getOption(ptr unsafe.Pointer, name string, searchFlags int32) (interface{},
 
 	dst := unsafe.Pointer(uintptr(target) + uintptr(opt.Offset))
 	switch opt.Type {
-	case avutil.OptionTypeBool:
-		return *(*int)(dst) != 0, nil
-
-	case avutil.OptionTypeFlags, avutil.OptionTypeInt:
-		return *(*int)(dst), nil
-
-	case avutil.OptionTypeInt64:
-		return *(*int64)(dst), nil
+	case avutil.OptionTypeBool, avutil.OptionTypeFlags, avutil.OptionTypeInt, avutil.OptionTypeInt64, avutil.OptionTypeUint64,
+		avutil.OptionTypeDuration, avutil.OptionTypeChannelLayout, avutil.OptionTypePixelFormat, avutil.OptionTypeSampleFormat:
+		var v int64
+		if err := averror(avutil.GetOptInt(target, name, 0, &v)); err != nil {
+			return nil, err
+		}
 
-	case avutil.OptionTypeUint64:
-		return *(*uint64)(dst), nil
+		return optionIntValue(opt.Type, v), nil
 
 	case avutil.OptionTypeFloat, avutil.OptionTypeDouble:
-		return *(*float64)(dst), nil
+		var v float64
+		if err := averror(avutil.GetOptDouble(target, name, 0, &v)); err != nil {
+			return nil, err
+		}
+
+		return v, nil
 
 	case avutil.OptionTypeVideoRate, avutil.OptionTypeRational:
-		return *(*avutil.Rational)(dst), nil
+		var v avutil.Rational
+		if err := averror(avutil.GetOptRational(target, name, 0, &v)); err != nil {
+			return nil, err
+		}
+
+		return v, nil
+
+	case avutil.OptionTypeString:
+		var v *uint8
+		if err := averror(avutil.GetOpt(target, name, 0, &v)); err != nil {
+			return nil, err
+		}
+
+		defer avutil.Free(unsafe.Pointer(v))
+
+		return (*common.CChar)(unsafe.Pointer(v)).String(), nil
+
+	case avutil.OptionTypeBinary:
+		// the binary data is followed by its length
+		data := *(*unsafe.Pointer)(dst)
+		size := *(*int32)(unsafe.Pointer(uintptr(dst) + unsafe.Sizeof(data)))
+		if data == nil {
+			return []byte(nil), nil
+		}
+
+		return append([]byte(nil), *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
+			Data: uintptr(data),
+			Len:  int(size),
+			Cap:  int(size),
+		}))...), nil
+
+	case avutil.OptionTypeDict:
+		var dict *avutil.Dictionary
+		if err := averror(avutil.GetOptDict(target, name, 0, &dict)); err != nil {
+			return nil, err
+		}
+
+		defer avutil.FreeDict(&dict)
+
+		return dictToMap(dict), nil
+
+	case avutil.OptionTypeImageSize:
+		var v ImageSize
+		if err := averror(avutil.GetOptImageSize(target, name, 0, &v.Width, &v.Height)); err != nil {
+			return nil, err
+		}
+
+		return v, nil
+
+	case avutil.OptionTypeColor:
+		rgba := (*[4]uint8)(dst)
+		return color.NRGBA{R: rgba[0], G: rgba[1], B: rgba[2], A: rgba[3]}, nil
 
 	case avutil.OptionTypeConst:
-		return *(*float64)(unsafe.Pointer(&opt.DefaultVal[0])), nil
+		// constants are stored as integers, even for options of other types,
+		// so they are returned as int64 like OptionConstant.Value
+		return *(*int64)(unsafe.Pointer(&opt.DefaultVal[0])), nil
 	}
 
 	return nil, avutil.ErrInval
 }
+
+// OptionInfo describes an option of a libav object.
+type OptionInfo struct {
+	Name string
+	Help string
+	Type avutil.OptionType
+
+	// Default is the default value of the option, which has the same type as
+	// the values returned by GetOption. It is nil if the default value could
+	// not be determined.
+	Default interface{}
+
+	// Min and Max are the range of numeric options.
+	Min float64
+	Max float64
+
+	// Flags is a combination of avutil.OptionFlag* values.
+	Flags int32
+
+	// Unit is the name of the group of named constants that are accepted by
+	// the option, if any.
+	Unit string
+
+	// Constants are the named values that are accepted by the option in
+	// addition to its regular values.
+	Constants []OptionConstant
+}
+
+// OptionConstant is a named value of an option.
+type OptionConstant struct {
+	Name string
+	Help string
+
+	// Value is the integer value of the constant, which is also what
+	// GetOption returns for the name of a constant.
+	Value int64
+}
+
+func optionDefault(opt *avutil.Option) interface{} {
+	p := unsafe.Pointer(&opt.DefaultVal[0])
+
+	switch opt.Type {
+	case avutil.OptionTypeFloat, avutil.OptionTypeDouble:
+		return *(*float64)(p)
+
+	case avutil.OptionTypeRational:
+		return avutil.DoubleToRational(*(*float64)(p), math.MaxInt32)
+
+	case avutil.OptionTypeBinary:
+		return nil
+	}
+
+	str := *(**common.CChar)(p)
+
+	switch opt.Type {
+	case avutil.OptionTypeString:
+		return str.String()
+
+	case avutil.OptionTypeVideoRate:
+		var v avutil.Rational
+		if str == nil || avutil.ParseVideoRate(&v, str.String()) < 0 {
+			return nil
+		}
+
+		return v
+
+	case avutil.OptionTypeImageSize:
+		var v ImageSize
+		if str == nil || avutil.ParseVideoSize(&v.Width, &v.Height, str.String()) < 0 {
+			return nil
+		}
+
+		return v
+
+	case avutil.OptionTypeColor:
+		var rgba [4]uint8
+		if str == nil || avutil.ParseColor(&rgba[0], str.String(), -1, nil) < 0 {
+			return nil
+		}
+
+		return color.NRGBA{R: rgba[0], G: rgba[1], B: rgba[2], A: rgba[3]}
+
+	case avutil.OptionTypeDict:
+		var dict *avutil.Dictionary
+		defer avutil.FreeDict(&dict)
+
+		if str != nil && avutil.ParseDictString(&dict, str.String(), "=", ":", 0) < 0 {
+			return nil
+		}
+
+		return dictToMap(dict)
+	}
+
+	return optionIntValue(opt.Type, *(*int64)(p))
+}
+
+// listOptions returns the options of obj and of its children, like the
+// private options of codecs and formats.
+func listOptions(obj unsafe.Pointer) []OptionInfo {
+	if obj == nil {
+		return nil
+	}
+
+	var opts []OptionInfo
+	constants := map[string][]OptionConstant{}
+	for opt := avutil.NextOpt(obj, nil); opt != nil; opt = avutil.NextOpt(obj, opt) {
+		if opt.Type == avutil.OptionTypeConst {
+			unit := opt.Unit.String()
+			constants[unit] = append(constants[unit], OptionConstant{
+				Name:  opt.Name.String(),
+				Help:  opt.Help.String(),
+				Value: *(*int64)(unsafe.Pointer(&opt.DefaultVal[0])),
+			})
+
+			continue
+		}
+
+		opts = append(opts, OptionInfo{
+			Name:    opt.Name.String(),
+			Help:    opt.Help.String(),
+			Type:    opt.Type,
+			Default: optionDefault(opt),
+			Min:     opt.Min,
+			Max:     opt.Max,
+			Flags:   opt.Flags,
+			Unit:    opt.Unit.String(),
+		})
+	}
+
+	for i := range opts {
+		if opts[i].Unit != "" {
+			opts[i].Constants = constants[opts[i].Unit]
+		}
+	}
+
+	for child := avutil.NextOptChild(obj, nil); child != nil; child = avutil.NextOptChild(obj, child) {
+		opts = append(opts, listOptions(child)...)
+	}
+
+	return opts
+}
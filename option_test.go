@@ -0,0 +1,183 @@
+package av_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+func newTestEncoder(t *testing.T, name string) *av.EncoderContext {
+	t.Helper()
+
+	codec, err := av.FindEncoderCodecByName(name)
+	if err != nil {
+		t.Skipf("%s is not available: %v", name, err)
+	}
+
+	ctx, err := av.NewEncoderContext(codec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(ctx.Free)
+
+	return ctx
+}
+
+func TestCodecOptionPrecedence(t *testing.T) {
+	ctx := newTestEncoder(t, "libx264")
+
+	// libx264 has a string profile option that shadows the generic integer
+	// profile option of the codec context
+	if err := ctx.SetOption("profile", "high"); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := ctx.GetOption("profile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != "high" {
+		t.Errorf("got profile %#v, want \"high\"", v)
+	}
+}
+
+func TestCodecGenericOption(t *testing.T) {
+	ctx := newTestEncoder(t, "mpeg4")
+
+	if err := ctx.SetOption("b", 500000); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := ctx.GetOption("b"); err != nil {
+		t.Fatal(err)
+	} else if v != int64(500000) {
+		t.Errorf("got bit rate %#v, want 500000", v)
+	}
+
+	if ctx.BitRate != 500000 {
+		t.Errorf("got BitRate %d, want 500000", ctx.BitRate)
+	}
+}
+
+func TestCodecConstantOption(t *testing.T) {
+	ctx := newTestEncoder(t, "mpeg4")
+
+	var constant *av.OptionConstant
+	for _, opt := range ctx.Options() {
+		if opt.Name != "flags" {
+			continue
+		}
+
+		for i := range opt.Constants {
+			if opt.Constants[i].Name == "qscale" {
+				constant = &opt.Constants[i]
+			}
+		}
+	}
+
+	if constant == nil {
+		t.Fatal("qscale constant of the flags option not found")
+	}
+
+	v, err := ctx.GetOption("qscale")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != constant.Value {
+		t.Errorf("got %#v, want int64 %d", v, constant.Value)
+	}
+}
+
+// assertUnconsumed checks that err reports exactly the given options as
+// unconsumed.
+func assertUnconsumed(t *testing.T, err error, names ...string) {
+	t.Helper()
+
+	var unconsumed av.UnconsumedOptionsError
+	if !errors.As(err, &unconsumed) {
+		t.Fatalf("got %v, want UnconsumedOptionsError", err)
+	}
+
+	if !reflect.DeepEqual([]string(unconsumed), names) {
+		t.Errorf("got unconsumed options %v, want %v", unconsumed, names)
+	}
+}
+
+func TestOpenInputUnconsumedOptions(t *testing.T) {
+	input, err := av.OpenInputWithOpener(av.FileOpener, testMediaFile, av.StringOption("probesize", "5000000"), av.StringOption("probsize", "1"))
+	if input != nil {
+		input.Close()
+		t.Error("got an input for rejected options")
+	}
+
+	assertUnconsumed(t, err, "probsize")
+
+	input, err = av.OpenInputWithOpener(av.FileOpener, testMediaFile, av.StringOption("probesize", "5000000"))
+	if err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	input.Close()
+}
+
+func TestDecoderUnconsumedOptions(t *testing.T) {
+	stream := openTestMedia(t).Stream(0)
+
+	codec, err := av.FindDecoderCodecByID(stream.Codecpar().CodecID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder, err := av.NewDecoderContext(codec, stream.Codecpar(), av.StringOption("threads", "2"), av.StringOption("thread", "2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer decoder.Free()
+
+	assertUnconsumed(t, decoder.Open(), "thread")
+
+	// the codec was opened regardless, so later calls succeed
+	if err := decoder.SendPacket(nil); err != nil {
+		t.Errorf("SendPacket after unconsumed options: %v", err)
+	}
+}
+
+func TestWriteHeaderUnconsumedOptions(t *testing.T) {
+	in := openTestMedia(t).Stream(0)
+
+	var buf bytes.Buffer
+	output, err := av.NewWriterOutputContext("matroska", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer output.Free()
+
+	out := output.NewStream(nil)
+	out.SetCodecpar(in.Codecpar())
+	out.Codecpar().CodecTag = 0
+	out.TimeBase = in.TimeBase
+
+	assertUnconsumed(t, output.WriteHeader(av.StringOption("reserve_index_space", "1024"), av.StringOption("reserve_idx_space", "1024")), "reserve_idx_space")
+
+	// the header was written regardless
+	if err := output.WriteHeader(); err != nil {
+		t.Errorf("second WriteHeader: %v", err)
+	}
+
+	if err := output.Close(); err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	if buf.Len() == 0 {
+		t.Error("nothing was written")
+	}
+}
@@ -1,13 +1,13 @@
 package av
 
-// #include <libavformat/avformat.h>
-import "C"
 import (
-	"errors"
+	"context"
 	"io"
 	"runtime"
 	"sync"
+	"unsafe"
 
+	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avcodec"
 	"github.com/ssttevee/go-av/avformat"
 	"github.com/ssttevee/go-av/avutil"
@@ -15,23 +15,31 @@ import (
 )
 
 type outputDest interface {
-	initIOContext(pb **avformat.IOContext) (func() error, error)
+	// initIOContext opens the io context of the output. Blocking operations
+	// are aborted by interruptCallback, which points to the
+	// AVIOInterruptCB of the format context.
+	initIOContext(pb **avformat.IOContext, interruptCallback unsafe.Pointer) (func() error, error)
 }
 
 type writerOutputDest struct {
 	w io.Writer
 }
 
-func (dst writerOutputDest) initIOContext(pb **avformat.IOContext) (func() error, error) {
-	*pb = allocAvioContext(dst.w, true)
-	return nil, nil
+func (dst writerOutputDest) initIOContext(pb **avformat.IOContext, interruptCallback unsafe.Pointer) (func() error, error) {
+	ioctx := newIOContext(dst.w, true)
+	*pb = ioctx._ioContext
+
+	return func() error {
+		ioctx.free()
+		return nil
+	}, nil
 }
 
 type fileOutputDest string
 
-func (dst fileOutputDest) initIOContext(pb **avformat.IOContext) (func() error, error) {
+func (dst fileOutputDest) initIOContext(pb **avformat.IOContext, interruptCallback unsafe.Pointer) (func() error, error) {
 	var ctx *avformat.IOContext
-	if err := averror(avformat.OpenIO(&ctx, string(dst), avformat.IOFlagWrite)); err != nil {
+	if err := averror(avformat.OpenIO2(&ctx, string(dst), avformat.IOFlagWrite, interruptCallback, nil)); err != nil {
 		return nil, err
 	}
 
@@ -47,9 +55,11 @@ type OutputFormatContext struct {
 
 	dst outputDest
 
-	initOnce sync.Once
-	initErr  error
+	initOnce      sync.Once
+	initErr       error
+	headerWritten bool
 
+	// closeFunc closes the io context, if it was opened
 	closeFunc func() error
 	closeOnce sync.Once
 	closeErr  error
@@ -85,18 +95,49 @@ func newOutputContext(formatName string, filename string) (*OutputFormatContext,
 
 	ctx.Opaque = nil
 
+	trackPointer(FormatContextObject, unsafe.Pointer(ctx))
+
 	ret := &OutputFormatContext{
 		formatContext: formatContext{
 			_formatContext: ctx,
 		},
 	}
 
-	runtime.SetFinalizer(ret, func(ctx *OutputFormatContext) {
-		ctx.finalizePinnedData()
+	runtime.SetFinalizer(ret, freeOutputFormatContext)
+
+	return ret, nil
+}
+
+func freeOutputFormatContext(ctx *OutputFormatContext) {
+	if ctx.freed() {
+		return
+	}
+
+	untrackPointer(FormatContextObject, unsafe.Pointer(ctx._formatContext))
+	ctx.finalizePinnedData(func() {
 		avformat.FreeContext(ctx._formatContext)
+		ctx._formatContext = nil
 	})
+}
 
-	return ret, nil
+// Free releases the context without writing the trailer, like when muxing
+// failed, and closes the output. It is safe to call Free more than once and
+// using the context afterwards returns ErrClosed.
+func (ctx *OutputFormatContext) Free() {
+	runtime.SetFinalizer(ctx, nil)
+	ctx.closeIO()
+	freeOutputFormatContext(ctx)
+}
+
+func (ctx *OutputFormatContext) closeIO() error {
+	if ctx.closeFunc == nil {
+		return nil
+	}
+
+	closeFunc := ctx.closeFunc
+	ctx.closeFunc = nil
+
+	return closeFunc()
 }
 
 func NewOutputContext(formatName string) (*OutputFormatContext, error) {
@@ -104,6 +145,10 @@ func NewOutputContext(formatName string) (*OutputFormatContext, error) {
 }
 
 func (ctx *OutputFormatContext) NewStream(codec *Codec) *Stream {
+	if ctx.freed() {
+		panic(ErrClosed)
+	}
+
 	var c *avcodec.Codec
 	if codec != nil {
 		c = codec._codec
@@ -122,26 +167,84 @@ func (ctx *OutputFormatContext) NewStream(codec *Codec) *Stream {
 	}
 }
 
+func (ctx *OutputFormatContext) formatName() string {
+	return ctx.Oformat.Name.String()
+}
+
+func (ctx *OutputFormatContext) formatFlags() int32 {
+	return ctx.Oformat.Flags
+}
+
+func (ctx *OutputFormatContext) OutputFormat() *OutputFormat {
+	return &OutputFormat{_outputFormat: ctx.Oformat}
+}
+
 func (ctx *OutputFormatContext) init() error {
+	return ctx.WriteHeader()
+}
+
+// WriteHeader opens the output and writes the header with the given muxer
+// options. It is called implicitly without options by the first WritePacket,
+// after which WriteHeader has no effect.
+func (ctx *OutputFormatContext) WriteHeader(opts ...Option) error {
+	if ctx.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
+	// unconsumed options do not prevent packets from being written after the
+	// header, so they are only reported by the call that writes it
+	var optionsErr error
 	ctx.initOnce.Do(func() {
+		dict, err := resolveOptionsDict(opts...)
+		if err != nil {
+			ctx.initErr = err
+			return
+		}
+
+		defer avutil.FreeDict(&dict)
+
+		logger, err := popLogger(&dict)
+		if err != nil {
+			ctx.initErr = err
+			return
+		}
+
+		if logger != nil {
+			ctx.SetLogger(logger)
+		}
+
 		if ctx.Flags&avformat.NoFile == 0 && ctx.dst != nil {
 			if ctx.dst == nil {
 				ctx.initErr = errors.New("missing output dest")
 				return
 			}
 
-			ctx.closeFunc, ctx.initErr = ctx.dst.initIOContext(&ctx.Pb)
+			// the interrupt callback is set up with the pinned data
+			ctx.pinnedData()
+			ctx.closeFunc, ctx.initErr = ctx.dst.initIOContext(&ctx.Pb, unsafe.Pointer(&ctx.InterruptCallback))
 			if ctx.initErr != nil {
 				return
 			}
+
+			if _, ok := ctx.dst.(writerOutputDest); ok {
+				unwrapPinnedFile(ctx.Pb.Opaque).owner = ctx.pinnedData()
+			}
 		}
 
-		if ctx.initErr = ctx.realError(averror(avformat.WriteHeader(ctx._formatContext, nil))); ctx.initErr != nil {
+		if ctx.initErr = ctx.realError(averror(avformat.WriteHeader(ctx._formatContext, &dict))); ctx.initErr != nil {
 			return
 		}
+
+		ctx.headerWritten = true
+
+		optionsErr = unconsumedOptionsError(dict)
 	})
 
-	return ctx.initErr
+	if ctx.initErr != nil {
+		return ctx.initErr
+	}
+
+	return optionsErr
 }
 
 func (ctx *OutputFormatContext) WritePacket(packet *Packet) error {
@@ -163,6 +266,15 @@ func (ctx *OutputFormatContext) WritePacket(packet *Packet) error {
 	return nil
 }
 
+// WritePacketContext is like WritePacket, but the write is aborted when ctx is
+// done. Writes that are already blocked can only be interrupted if the
+// underlying writer has a SetWriteDeadline method, like net.Conn.
+func (ctx *OutputFormatContext) WritePacketContext(c context.Context, packet *Packet) error {
+	return ctx.withContext(c, func() error {
+		return ctx.WritePacket(packet)
+	})
+}
+
 func (ctx *OutputFormatContext) realError(err error) error {
 	if averr, ok := errors.Unwrap(err).(avutil.Error); ok {
 		switch int(averr) {
@@ -177,22 +289,32 @@ func (ctx *OutputFormatContext) realError(err error) error {
 	return err
 }
 
+// Close writes the trailer, closes the output and releases the context. The
+// trailer is only written if the header was written. Using the context
+// afterwards returns ErrClosed.
 func (ctx *OutputFormatContext) Close() error {
 	ctx.closeOnce.Do(func() {
-		if ctx.closeErr = ctx.realError(averror(avformat.WriteTrailer(ctx._formatContext))); ctx.closeErr != nil {
+		if ctx.freed() {
 			return
 		}
 
-		if ctx.Flags&avformat.NoFile == 0 {
-			if ctx.closeFunc == nil {
-				return
-			}
+		if ctx.headerWritten {
+			ctx.closeErr = ctx.realError(averror(avformat.WriteTrailer(ctx._formatContext)))
+		}
 
-			if ctx.closeErr = ctx.closeFunc(); ctx.closeErr != nil {
-				return
-			}
+		if err := ctx.closeIO(); ctx.closeErr == nil {
+			ctx.closeErr = err
 		}
+
+		runtime.SetFinalizer(ctx, nil)
+		freeOutputFormatContext(ctx)
 	})
 
 	return ctx.closeErr
 }
+
+// CloseContext is like Close, but writing the trailer is aborted when ctx is
+// done.
+func (ctx *OutputFormatContext) CloseContext(c context.Context) error {
+	return ctx.withContext(c, ctx.Close)
+}
@@ -0,0 +1,89 @@
+package av
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avcodec"
+	"github.com/ssttevee/go-av/avformat"
+)
+
+type OutputFormatNotFoundError string
+
+func (e OutputFormatNotFoundError) Error() string {
+	return "output format not found: " + string(e)
+}
+
+type _outputFormat = avformat.OutputFormat
+
+type OutputFormat struct {
+	*_outputFormat
+}
+
+// FindOutputFormat returns the muxer with the given short name, like "mp4",
+// "mpegts" or "matroska".
+func FindOutputFormat(name string) (*OutputFormat, error) {
+	format := avformat.GuessFormat(name, "", "")
+	if format == nil {
+		return nil, errors.WithStack(OutputFormatNotFoundError(name))
+	}
+
+	return &OutputFormat{_outputFormat: format}, nil
+}
+
+func (f *OutputFormat) Name() string {
+	return f._outputFormat.Name.String()
+}
+
+func (f *OutputFormat) LongName() string {
+	return f._outputFormat.LongName.String()
+}
+
+// Extensions returns the comma separated file extensions of the format.
+func (f *OutputFormat) Extensions() string {
+	return f._outputFormat.Extensions.String()
+}
+
+// MimeType returns the comma separated mime types of the format.
+func (f *OutputFormat) MimeType() string {
+	return f._outputFormat.MimeType.String()
+}
+
+// SupportsCodec reports whether streams of the given codec can be stored in
+// the format. An error is returned if the format does not declare which
+// codecs it supports.
+func (f *OutputFormat) SupportsCodec(codecID avcodec.ID) (bool, error) {
+	ret, err := avreturn(avformat.QueryCodec(f._outputFormat, codecID, 0))
+	if err != nil {
+		return false, err
+	}
+
+	return ret == 1, nil
+}
+
+// OutputFormatIterator iterates over the muxers in libavformat. The zero value
+// is ready to use.
+type OutputFormatIterator struct {
+	opaque unsafe.Pointer
+}
+
+// Next returns the next muxer, or nil once all muxers have been returned.
+func (it *OutputFormatIterator) Next() *OutputFormat {
+	format := avformat.IterateMuxers(&it.opaque)
+	if format == nil {
+		return nil
+	}
+
+	return &OutputFormat{_outputFormat: format}
+}
+
+// OutputFormats returns all muxers in libavformat.
+func OutputFormats() []*OutputFormat {
+	var formats []*OutputFormat
+	var it OutputFormatIterator
+	for format := it.Next(); format != nil; format = it.Next() {
+		formats = append(formats, format)
+	}
+
+	return formats
+}
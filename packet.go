@@ -1,8 +1,17 @@
 package av
 
+// #include <stdint.h>
+//
+// extern void goavPacketBufferFree(void *opaque, uint8_t *data);
+import "C"
 import (
+	"math"
+	"reflect"
 	"runtime"
+	"runtime/cgo"
+	"unsafe"
 
+	"github.com/pkg/errors"
 	"github.com/ssttevee/go-av/avcodec"
 	"github.com/ssttevee/go-av/avutil"
 )
@@ -23,17 +32,37 @@ func NewPacket() *Packet {
 		_packet: packet,
 	}
 
-	runtime.SetFinalizer(ret, func(p *Packet) {
-		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
-		packet := (*avcodec.Packet)(p._packet)
-		avcodec.FreePacket(&packet)
-		p._packet = packet
-	})
+	trackPointer(PacketObject, unsafe.Pointer(packet))
+	runtime.SetFinalizer(ret, freePacket)
 
 	return ret
 }
 
+func freePacket(p *Packet) {
+	untrackPointer(PacketObject, unsafe.Pointer(p._packet))
+	// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+	packet := (*avcodec.Packet)(p._packet)
+	avcodec.FreePacket(&packet)
+	p._packet = packet
+}
+
+// Free releases the packet and its reference to the payload without waiting
+// for the garbage collector. It is safe to call Free more than once, but the
+// packet must not be used afterwards.
+func (p *Packet) Free() {
+	runtime.SetFinalizer(p, nil)
+	freePacket(p)
+}
+
+func (p *Packet) freed() bool {
+	return p._packet == nil
+}
+
 func (p *Packet) Rescale(src, dst avutil.Rational) {
+	if p.freed() {
+		return
+	}
+
 	p.Pts = avutil.RescaleQRound(p.Pts, src, dst, avutil.RoundingNearInfinity|avutil.RoundingPassMinMax)
 	p.Dts = avutil.RescaleQRound(p.Dts, src, dst, avutil.RoundingNearInfinity|avutil.RoundingPassMinMax)
 	p.Duration = avutil.RescaleQ(p.Duration, src, dst)
@@ -46,12 +75,17 @@ func (p *Packet) prepare() *avcodec.Packet {
 }
 
 func (p *Packet) CopyTo(p2 *Packet) error {
+	if p.freed() || p2.freed() {
+		return errors.WithStack(ErrClosed)
+	}
+
 	return averror(avcodec.RefPacket(p2._packet, p._packet))
 }
 
 func (p *Packet) Clone() (*Packet, error) {
 	clone := NewPacket()
 	if err := p.CopyTo(clone); err != nil {
+		clone.Free()
 		return nil, err
 	}
 
@@ -59,5 +93,119 @@ func (p *Packet) Clone() (*Packet, error) {
 }
 
 func (p *Packet) Unref() {
+	if p.freed() {
+		return
+	}
+
 	avcodec.UnrefPacket(p._packet)
 }
+
+// NewPacketFromBytes returns a packet with a copy of b as its payload. The
+// packet owns the copy, so b may be reused once this returns.
+func NewPacketFromBytes(b []byte) (*Packet, error) {
+	if len(b) > math.MaxInt32-avcodec.InputBufferPaddingSize {
+		return nil, errors.Errorf("packet size too large: %d", len(b))
+	}
+
+	p := NewPacket()
+	if err := averror(avcodec.AllocPacketData(p._packet, int32(len(b)))); err != nil {
+		return nil, err
+	}
+
+	copy(p.Bytes(), b)
+
+	return p, nil
+}
+
+//export goavPacketBufferFree
+func goavPacketBufferFree(opaque unsafe.Pointer, data *C.uint8_t) {
+	handle := cgo.Handle(opaque)
+	defer deleteHandle(handle)
+
+	if release := handle.Value().(func()); release != nil {
+		release()
+	}
+}
+
+// WrapPacketData returns a packet with the size bytes at data as its payload
+// without copying them. release is called once the packet and all of its
+// references are freed, which may happen on any goroutine.
+//
+// Memory referenced by libav may not be managed by go, so data must be
+// allocated in C, for example with avutil.Malloc. It must also be at least
+// size+avcodec.InputBufferPaddingSize bytes long, with the padding zeroed, as
+// decoders and parsers may read past the end of the payload.
+func WrapPacketData(data unsafe.Pointer, size int, release func()) (*Packet, error) {
+	if size < 0 || size > math.MaxInt32-avcodec.InputBufferPaddingSize {
+		return nil, errors.Errorf("invalid packet size: %d", size)
+	}
+
+	handle := newHandle(release)
+
+	buf := avutil.CreateBuffer((*uint8)(data), int32(size+avcodec.InputBufferPaddingSize), unsafe.Pointer(C.goavPacketBufferFree), unsafe.Pointer(handle), 0)
+	if buf == nil {
+		deleteHandle(handle)
+		panic(avutil.ErrNoMem)
+	}
+
+	p := NewPacket()
+	p.Buf = buf
+	p.Data = (*uint8)(data)
+	p.Size = int32(size)
+
+	return p, nil
+}
+
+// Bytes returns the payload of the packet. The slice refers to memory owned by
+// the packet, so it is only valid until the packet is unreferenced or freed.
+func (p *Packet) Bytes() []byte {
+	if p.freed() || p.Data == nil {
+		return nil
+	}
+
+	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
+		Data: uintptr(unsafe.Pointer(p.Data)),
+		Len:  int(p.Size),
+		Cap:  int(p.Size),
+	}))
+}
+
+func (p *Packet) setFlag(flag int32, v bool) {
+	if p.freed() {
+		return
+	}
+
+	if v {
+		p.Flags |= flag
+	} else {
+		p.Flags &^= flag
+	}
+}
+
+// IsKeyframe reports whether the packet contains a keyframe.
+func (p *Packet) IsKeyframe() bool {
+	return !p.freed() && p.Flags&avcodec.PacketFlagKey != 0
+}
+
+func (p *Packet) SetKeyframe(v bool) {
+	p.setFlag(avcodec.PacketFlagKey, v)
+}
+
+// IsCorrupt reports whether the packet is known to contain corrupted data.
+func (p *Packet) IsCorrupt() bool {
+	return !p.freed() && p.Flags&avcodec.PacketFlagCorrupt != 0
+}
+
+func (p *Packet) SetCorrupt(v bool) {
+	p.setFlag(avcodec.PacketFlagCorrupt, v)
+}
+
+// IsDiscarded reports whether the packet is only needed to decode other
+// packets and its output should be discarded.
+func (p *Packet) IsDiscarded() bool {
+	return !p.freed() && p.Flags&avcodec.PacketFlagDiscard != 0
+}
+
+func (p *Packet) SetDiscarded(v bool) {
+	p.setFlag(avcodec.PacketFlagDiscard, v)
+}
@@ -0,0 +1,72 @@
+package av
+
+import (
+	"sync"
+)
+
+// The pools only recycle the packet and frame structs, not their data. An
+// AVBufferPool is not used for the data since the buffers of decoded frames
+// are already pooled by libavcodec, while the payloads of demuxed and encoded
+// packets are allocated by libav, which does not accept a pool for them.
+
+// packetPool and framePool are used by the helpers of the package, like Remux
+// and Pipeline, so that repeated runs reuse the same packets and frames.
+var (
+	packetPool PacketPool
+	framePool  FramePool
+)
+
+// PacketPool recycles packets so that reading, encoding and filtering in a
+// loop does not allocate a new packet each time. It is safe for concurrent
+// use and the zero value is ready to use.
+type PacketPool struct {
+	pool sync.Pool
+}
+
+// Get returns an empty packet from the pool, or a new one if the pool is
+// empty.
+func (p *PacketPool) Get() *Packet {
+	if packet, ok := p.pool.Get().(*Packet); ok {
+		return packet
+	}
+
+	return NewPacket()
+}
+
+// Put unreferences the payload of the packet and returns it to the pool. The
+// packet must not be used afterwards.
+func (p *PacketPool) Put(packet *Packet) {
+	if packet == nil || packet.freed() {
+		return
+	}
+
+	packet.Unref()
+	p.pool.Put(packet)
+}
+
+// FramePool recycles frames so that decoding and filtering in a loop does not
+// allocate a new frame each time. It is safe for concurrent use and the zero
+// value is ready to use.
+type FramePool struct {
+	pool sync.Pool
+}
+
+// Get returns an empty frame from the pool, or a new one if the pool is empty.
+func (p *FramePool) Get() *Frame {
+	if frame, ok := p.pool.Get().(*Frame); ok {
+		return frame
+	}
+
+	return NewFrame()
+}
+
+// Put unreferences the data of the frame and returns it to the pool. The frame
+// must not be used afterwards.
+func (p *FramePool) Put(frame *Frame) {
+	if frame == nil || frame.freed() {
+		return
+	}
+
+	frame.Unref()
+	p.pool.Put(frame)
+}
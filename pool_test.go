@@ -0,0 +1,79 @@
+package av_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+func TestReadPacketWithPool(t *testing.T) {
+	input := openClip(t, testClip(t, time.Second))
+
+	var pool av.PacketPool
+
+	var count int
+	for {
+		packet, err := input.ReadPacketWithPool(&pool)
+		if errors.Is(err, io.EOF) {
+			if packet != nil {
+				t.Error("got a packet at the end of the input")
+			}
+
+			break
+		} else if err != nil {
+			t.Fatal(fmterrors.FormatString(err))
+		}
+
+		if len(packet.Bytes()) == 0 {
+			t.Errorf("packet %d is empty", count)
+		}
+
+		count++
+		pool.Put(packet)
+	}
+
+	if count == 0 {
+		t.Fatal("no packets were read")
+	}
+
+	// packets from the pool never carry the payload of a previous read
+	packet := pool.Get()
+	defer packet.Free()
+
+	if packet.Bytes() != nil || packet.StreamIndex != 0 {
+		t.Error("got a packet that was not unreferenced")
+	}
+}
+
+func TestFramePool(t *testing.T) {
+	input := openClip(t, testClip(t, time.Second))
+
+	it, err := av.NewFrameIterator(input, 0)
+	if err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	defer it.Close()
+
+	var pool av.FramePool
+	for i := 0; i < 10; i++ {
+		frame := pool.Get()
+		if frame.Width != 0 || frame.Plane(0) != nil {
+			t.Fatalf("frame %d from the pool was not unreferenced", i)
+		}
+
+		if err := it.Next(frame); err != nil {
+			t.Fatal(fmterrors.FormatString(err))
+		}
+
+		if frame.Width == 0 || frame.Plane(0) == nil {
+			t.Fatalf("frame %d has no picture", i)
+		}
+
+		pool.Put(frame)
+	}
+}
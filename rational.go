@@ -1,6 +1,8 @@
 package av
 
 import (
+	"time"
+
 	"github.com/ssttevee/go-av/avutil"
 )
 
@@ -10,3 +12,11 @@ func Rat(num, den int32) avutil.Rational {
 		Den: den,
 	}
 }
+
+func durationToTimestamp(d time.Duration, timeBase avutil.Rational) int64 {
+	return avutil.RescaleQ(int64(d/time.Microsecond), avutil.Rat(1, avutil.TimeBase), timeBase)
+}
+
+func timestampToDuration(ts int64, timeBase avutil.Rational) time.Duration {
+	return time.Duration(avutil.RescaleQ(ts, timeBase, avutil.Rat(1, avutil.TimeBase))) * time.Microsecond
+}
@@ -0,0 +1,34 @@
+package av
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func TestDurationToTimestamp(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        time.Duration
+		timeBase avutil.Rational
+		want     int64
+	}{
+		{"zero", 0, Rat(1, 90000), 0},
+		{"microseconds", 1500 * time.Millisecond, Rat(1, avutil.TimeBase), 1500000},
+		{"mpegts", 2 * time.Second, Rat(1, 90000), 180000},
+		{"milliseconds", 1234 * time.Millisecond, Rat(1, 1000), 1234},
+		{"framerate", time.Second, Rat(1001, 30000), 30},
+		{"rounded", 10 * time.Millisecond, Rat(1, 30), 0},
+		{"negative", -time.Second, Rat(1, 48000), -48000},
+		{"sub microsecond", 999 * time.Nanosecond, Rat(1, 1000000000), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := durationToTimestamp(tt.d, tt.timeBase); got != tt.want {
+				t.Errorf("durationToTimestamp(%s, %s) = %d, want %d", tt.d, tt.timeBase, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package av
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avcodec"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// RemuxOptions configures Remux.
+type RemuxOptions struct {
+	// Streams are the indexes of the input streams to remux, in the order they
+	// will appear in the output. All streams are remuxed if it is nil.
+	Streams []int
+
+	// Start is the position in the input to start remuxing from. Packets
+	// before it are dropped and video streams start at their first keyframe
+	// at or after it, so that they can be decoded without the dropped
+	// packets. Timestamps are shifted so that Start becomes zero.
+	Start time.Duration
+
+	// End is the position in the input to stop remuxing at. Packets are
+	// remuxed until their decoding timestamp reaches it, so that frames that
+	// are reordered before End are kept. The input is remuxed until the end
+	// if it is zero.
+	//
+	// Start and End are relative to the start time of the input, which is not
+	// zero for some formats like mpegts.
+	End time.Duration
+
+	// BitstreamFilters maps input stream indexes to bitstream filter names. It
+	// overrides the filters that would otherwise be picked automatically for
+	// the output format, an empty name disables filtering.
+	BitstreamFilters map[int]string
+}
+
+// annexBFormats are the output formats that require h264 and hevc streams to
+// be in annex b format.
+var annexBFormats = map[string]bool{
+	"mpegts": true,
+	"hls":    true,
+	"h264":   true,
+	"hevc":   true,
+}
+
+func defaultBitstreamFilterName(formatName string, codecID avcodec.ID) string {
+	if annexBFormats[formatName] {
+		switch codecID {
+		case avcodec.H264:
+			return "h264_mp4toannexb"
+
+		case avcodec.HEVC:
+			return "hevc_mp4toannexb"
+		}
+	}
+
+	return ""
+}
+
+type remuxStream struct {
+	in  *Stream
+	out *Stream
+
+	bsf      *BitstreamFilterContext
+	timeBase avutil.Rational
+
+	// filtered is reused for the output of the bitstream filter
+	filtered []*Packet
+
+	// trimStartTimestamp is the position of Start in the time base of the
+	// input stream and startTimestamp in the time base of the filtered
+	// packets
+	trimStartTimestamp int64
+	startTimestamp     int64
+	trimStart          bool
+	started            bool
+	video              bool
+
+	endTimestamp int64
+	trimEnd      bool
+	ended        bool
+}
+
+// Remux copies the packets of the selected input streams to the output
+// without decoding them, rescaling timestamps to the time bases chosen by the
+// output. Chapters within the remuxed range are copied as well. The output is
+// closed once the input has been remuxed. If remuxing fails, the output is
+// left open and should be freed by the caller.
+func Remux(ctx context.Context, input *InputFormatContext, output *OutputFormatContext, opts RemuxOptions) error {
+	indexes := opts.Streams
+	if indexes == nil {
+		for i := range input.Streams() {
+			indexes = append(indexes, i)
+		}
+	}
+
+	streams := make([]*remuxStream, len(input.Streams()))
+	defer func() {
+		for _, s := range streams {
+			if s != nil && s.bsf != nil {
+				s.bsf.Free()
+			}
+		}
+	}()
+
+	for _, i := range indexes {
+		if i < 0 || i >= len(streams) {
+			return errors.Errorf("stream index %d not found in input", i)
+		}
+
+		if streams[i] != nil {
+			return errors.Errorf("stream index %d selected more than once", i)
+		}
+
+		s, err := newRemuxStream(input, input.Stream(i), output, opts)
+		if err != nil {
+			return errors.WithMessagef(err, "stream %d", i)
+		}
+
+		streams[i] = s
+	}
+
+	if err := output.CopyChapters(input, opts.Start, opts.End); err != nil {
+		return err
+	}
+
+	if opts.Start > 0 {
+		if err := input.SeekTo(opts.Start); err != nil {
+			return err
+		}
+	}
+
+	// writes to the output are aborted when ctx is done as well
+	return output.withContext(ctx, func() error {
+		return remux(ctx, input, output, streams)
+	})
+}
+
+func remux(ctx context.Context, input *InputFormatContext, output *OutputFormatContext, streams []*remuxStream) error {
+	if err := output.init(); err != nil {
+		return err
+	}
+
+	packet := packetPool.Get()
+	defer packetPool.Put(packet)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := input.ReadPacketReuseContext(ctx, packet); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if int(packet.StreamIndex) >= len(streams) {
+			continue
+		}
+
+		s := streams[packet.StreamIndex]
+		if s == nil || s.ended {
+			continue
+		}
+
+		if !s.start(packet) {
+			continue
+		}
+
+		if s.end(packet) {
+			s.ended = true
+			if allRemuxStreamsEnded(streams) {
+				break
+			}
+
+			continue
+		}
+
+		if err := s.writePacket(output, packet); err != nil {
+			return errors.WithMessagef(err, "stream %d", packet.StreamIndex)
+		}
+	}
+
+	for i, s := range streams {
+		if s == nil {
+			continue
+		}
+
+		if err := s.flush(output); err != nil {
+			return errors.WithMessagef(err, "stream %d", i)
+		}
+	}
+
+	return output.Close()
+}
+
+// start reports whether the packet is at or after the start of the remuxed
+// range. Packets before it would have negative timestamps once shifted.
+func (s *remuxStream) start(packet *Packet) bool {
+	if !s.trimStart || s.started {
+		return true
+	}
+
+	if packet.Pts == avutil.NoPtsValue || packet.Pts < s.trimStartTimestamp {
+		return false
+	}
+
+	// later video frames may depend on the dropped ones
+	if s.video && !packet.IsKeyframe() {
+		return false
+	}
+
+	s.started = true
+
+	return true
+}
+
+// end reports whether the packet is past the end of the remuxed range. The
+// decoding timestamp is used if there is one, since packets are in decoding
+// order and frames with a lower presentation timestamp may still follow.
+func (s *remuxStream) end(packet *Packet) bool {
+	if !s.trimEnd {
+		return false
+	}
+
+	ts := packet.Dts
+	if ts == avutil.NoPtsValue {
+		ts = packet.Pts
+	}
+
+	return ts != avutil.NoPtsValue && ts >= s.endTimestamp
+}
+
+func allRemuxStreamsEnded(streams []*remuxStream) bool {
+	for _, s := range streams {
+		if s != nil && !s.ended {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRemuxStream(input *InputFormatContext, in *Stream, output *OutputFormatContext, opts RemuxOptions) (*remuxStream, error) {
+	s := &remuxStream{
+		in:       in,
+		timeBase: in.TimeBase,
+		video:    in.Codecpar().CodecType == avutil.Video,
+	}
+
+	// the positions are relative to the start time of the input instead of
+	// the stream, so that all streams are trimmed and shifted alike
+	if opts.End > 0 {
+		s.endTimestamp = input.timestamp(opts.End, in.TimeBase)
+		s.trimEnd = true
+	}
+
+	bsfName, ok := opts.BitstreamFilters[int(in.Index)]
+	if !ok {
+		bsfName = defaultBitstreamFilterName(output.formatName(), in.Codecpar().CodecID)
+	}
+
+	params := in.Codecpar()
+	if bsfName != "" {
+		filter, err := FindBitstreamFilterByName(bsfName)
+		if err != nil {
+			return nil, err
+		}
+
+		s.bsf, err = NewBitstreamFilterContext(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		s.bsf.SetInputCodecParameters(params)
+		s.bsf.SetInputTimeBase(in.TimeBase)
+
+		if err := s.bsf.Init(); err != nil {
+			return nil, err
+		}
+
+		params = s.bsf.OutputCodecParameters()
+		s.timeBase = s.bsf.OutputTimeBase()
+	}
+
+	if opts.Start > 0 {
+		s.trimStartTimestamp = input.timestamp(opts.Start, in.TimeBase)
+		s.startTimestamp = input.timestamp(opts.Start, s.timeBase)
+		s.trimStart = true
+	}
+
+	s.out = output.NewStream(nil)
+	s.out.SetCodecpar(params)
+	s.out.TimeBase = s.timeBase
+
+	// the codec tag of the input container may not be valid in the output
+	// container, so let the muxer pick one instead
+	s.out._stream.Codecpar.CodecTag = 0
+
+	return s, nil
+}
+
+func (s *remuxStream) writePacket(output *OutputFormatContext, packet *Packet) error {
+	if s.bsf == nil {
+		return s.writeFilteredPacket(output, packet)
+	}
+
+	return s.filterPacket(output, packet)
+}
+
+// filterPacket filters packet, which is nil to flush the filter, and writes
+// the filtered packets.
+func (s *remuxStream) filterPacket(output *OutputFormatContext, packet *Packet) error {
+	var err error
+	s.filtered, err = s.bsf.FilterPacketWithPool(packet, &packetPool, s.filtered[:0])
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		for _, p := range s.filtered {
+			packetPool.Put(p)
+		}
+	}()
+
+	for _, p := range s.filtered {
+		if err := s.writeFilteredPacket(output, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *remuxStream) writeFilteredPacket(output *OutputFormatContext, packet *Packet) error {
+	if packet.Pts != avutil.NoPtsValue {
+		packet.Pts -= s.startTimestamp
+	}
+
+	// the decoding timestamps of the first packets may still be negative if
+	// frames are reordered, which muxers handle like for any stream with
+	// b-frames
+	if packet.Dts != avutil.NoPtsValue {
+		packet.Dts -= s.startTimestamp
+	}
+
+	packet.StreamIndex = s.out.Index
+	packet.Pos = -1
+	packet.Rescale(s.timeBase, s.out.TimeBase)
+
+	return output.WritePacket(packet)
+}
+
+func (s *remuxStream) flush(output *OutputFormatContext) error {
+	if s.bsf == nil {
+		return nil
+	}
+
+	return s.filterPacket(output, nil)
+}
@@ -0,0 +1,157 @@
+package av_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+func remuxTestMedia(t *testing.T, formatName string, opts av.RemuxOptions) *av.InputFormatContext {
+	t.Helper()
+
+	var buf bytes.Buffer
+	output, err := av.NewWriterOutputContext(formatName, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer output.Free()
+
+	if err := av.Remux(context.Background(), openTestMedia(t), output, opts); err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	return openClip(t, buf.Bytes())
+}
+
+// readAll reads the remaining packets of the input, grouped by stream.
+func readAll(t *testing.T, input *av.InputFormatContext) map[int32][]*av.Packet {
+	t.Helper()
+
+	packets := map[int32][]*av.Packet{}
+	for {
+		packet, err := input.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(packet.Free)
+		packets[packet.StreamIndex] = append(packets[packet.StreamIndex], packet)
+	}
+
+	return packets
+}
+
+func ptsDuration(input *av.InputFormatContext, packet *av.Packet) time.Duration {
+	timeBase := input.Stream(int(packet.StreamIndex)).TimeBase
+	return time.Duration(avutil.RescaleQ(packet.Pts, timeBase, avutil.Rat(1, 1000000))) * time.Microsecond
+}
+
+func TestRemuxTrim(t *testing.T) {
+	result := remuxTestMedia(t, "matroska", av.RemuxOptions{
+		Start: 3 * time.Second,
+		End:   5 * time.Second,
+	})
+
+	packets := readAll(t, result)
+	if len(packets) != len(result.Streams()) {
+		t.Fatalf("got packets of %d streams, want %d", len(packets), len(result.Streams()))
+	}
+
+	for i, stream := range packets {
+		if result.Stream(int(i)).Codecpar().CodecType == avutil.Video && !stream[0].IsKeyframe() {
+			t.Errorf("stream %d does not start with a keyframe", i)
+		}
+
+		var last time.Duration
+		for _, packet := range stream {
+			pts := ptsDuration(result, packet)
+			if pts < 0 {
+				t.Fatalf("stream %d has a packet with pts %s before the start", i, pts)
+			}
+
+			if pts > last {
+				last = pts
+			}
+		}
+
+		// reordered frames may end slightly after End
+		if last < 1800*time.Millisecond || last > 2200*time.Millisecond {
+			t.Errorf("stream %d ends at %s, want about 2s", i, last)
+		}
+	}
+}
+
+func TestRemuxStreams(t *testing.T) {
+	input := openTestMedia(t)
+	audioIndex, _, err := input.FindBestStream(avutil.Audio)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := remuxTestMedia(t, "matroska", av.RemuxOptions{
+		Streams: []int{audioIndex},
+		End:     time.Second,
+	})
+
+	if n := len(result.Streams()); n != 1 {
+		t.Fatalf("got %d streams, want 1", n)
+	}
+
+	if codecType := result.Stream(0).Codecpar().CodecType; codecType != avutil.Audio {
+		t.Errorf("got a %s stream, want audio", codecType)
+	}
+}
+
+func TestRemuxAnnexB(t *testing.T) {
+	input := openTestMedia(t)
+	videoIndex, _, err := input.FindBestStream(avutil.Video)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// mpegts requires h264 in annex b format, so h264_mp4toannexb is inserted
+	result := remuxTestMedia(t, "mpegts", av.RemuxOptions{
+		Streams: []int{videoIndex},
+		End:     time.Second,
+	})
+
+	packets := readAll(t, result)[0]
+	if len(packets) == 0 {
+		t.Fatal("got no packets")
+	}
+
+	b := packets[0].Bytes()
+	if !bytes.HasPrefix(b, []byte{0, 0, 0, 1}) && !bytes.HasPrefix(b, []byte{0, 0, 1}) {
+		t.Errorf("first packet does not start with a start code: % x", b[:4])
+	}
+}
+
+func TestRemuxInvalidStreams(t *testing.T) {
+	for _, indexes := range [][]int{{0, 0}, {-1}, {100}} {
+		output, err := av.NewOutputContext("null")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := av.Remux(context.Background(), openTestMedia(t), output, av.RemuxOptions{Streams: indexes}); err == nil {
+			t.Errorf("Remux with streams %v succeeded", indexes)
+		}
+
+		// the output is left to the caller on failure
+		if err := output.SetOption("avioflags", "direct"); errors.Is(err, av.ErrClosed) {
+			t.Errorf("output was released after remuxing streams %v failed", indexes)
+		}
+
+		output.Free()
+	}
+}
@@ -0,0 +1,161 @@
+package av
+
+import (
+	"runtime"
+
+	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-av/swresample"
+)
+
+// Resampler converts audio frames between sample formats, sample rates and
+// channel layouts.
+type Resampler struct {
+	ctx *swresample.Context
+
+	src AudioFormat
+	dst AudioFormat
+
+	nextPts int64
+}
+
+func NewResampler(src, dst AudioFormat) (*Resampler, error) {
+	ctx := swresample.AllocSetOpts(nil, int64(dst.ChannelLayout), dst.SampleFormat, dst.SampleRate, int64(src.ChannelLayout), src.SampleFormat, src.SampleRate, 0, nil)
+	if ctx == nil {
+		panic(avutil.ErrNoMem)
+	}
+
+	ret := &Resampler{
+		ctx:     ctx,
+		src:     src,
+		dst:     dst,
+		nextPts: avutil.NoPtsValue,
+	}
+
+	runtime.SetFinalizer(ret, func(r *Resampler) {
+		// heap pointer may not be passed to cgo, so use a stack pointer instead :D
+		swrCtx := r.ctx
+		swresample.FreeContext(&swrCtx)
+		r.ctx = swrCtx
+	})
+
+	if err := averror(swresample.Init(ctx)); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// NewResamplerForEncoder creates a resampler that converts frames produced by
+// the decoder into a format that is accepted by the encoder.
+func NewResamplerForEncoder(decoder *DecoderContext, encoder *EncoderContext) (*Resampler, error) {
+	return NewResampler(decoder.AudioFormat(), encoder.AudioFormat())
+}
+
+func (r *Resampler) SourceFormat() AudioFormat {
+	return r.src
+}
+
+func (r *Resampler) DestinationFormat() AudioFormat {
+	return r.dst
+}
+
+// Delay returns the number of buffered samples in the destination sample
+// rate that have not yet been output.
+func (r *Resampler) Delay() int64 {
+	return swresample.GetDelay(r.ctx, int64(r.dst.SampleRate))
+}
+
+// nextFramePts computes the pts of the next output frame in 1/dst.SampleRate
+// units, or avutil.NoPtsValue if it is unknown.
+func (r *Resampler) nextFramePts(frame *Frame) int64 {
+	if frame == nil || frame._frame.Pts == avutil.NoPtsValue {
+		return r.nextPts
+	}
+
+	// timestamps are expressed in units of 1/(src.SampleRate*dst.SampleRate)
+	// so that no precision is lost when the sample rates differ
+	srcTimeBase := r.src.timeBase()
+	pts := avutil.RescaleRound(frame._frame.Pts, int64(srcTimeBase.Num)*int64(r.src.SampleRate)*int64(r.dst.SampleRate), int64(srcTimeBase.Den), avutil.RoundingNearInfinity)
+	pts = swresample.NextPts(r.ctx, pts)
+
+	return avutil.RescaleRound(pts, 1, int64(r.src.SampleRate), avutil.RoundingNearInfinity)
+}
+
+// ResampleFrameReuse converts the frame and writes the converted samples to
+// out. If frame is nil, buffered samples are flushed. The out frame will have
+// zero samples if no output is available yet.
+func (r *Resampler) ResampleFrameReuse(out *Frame, frame *Frame) error {
+	var in *avutil.Frame
+	if frame != nil {
+		in = frame._frame
+		if in.ChannelLayout == 0 {
+			// the default layout is set on a new reference to the samples, so
+			// that the frame of the caller is left untouched
+			ref := framePool.Get()
+			defer framePool.Put(ref)
+
+			if err := frame.CopyTo(ref); err != nil {
+				return err
+			}
+
+			ref._frame.ChannelLayout = channelLayoutOrDefault(0, in.Channels)
+			in = ref._frame
+		}
+	}
+
+	pts := r.nextFramePts(frame)
+
+	dst := out.prepare()
+	dst.Format = int32(r.dst.SampleFormat)
+	dst.SampleRate = r.dst.SampleRate
+	dst.ChannelLayout = r.dst.ChannelLayout
+	dst.Channels = r.dst.Channels()
+
+	defer runtime.KeepAlive(frame)
+
+	if err := averror(swresample.ConvertFrame(r.ctx, dst, in)); err != nil {
+		return err
+	}
+
+	if pts == avutil.NoPtsValue {
+		dst.Pts = avutil.NoPtsValue
+	} else {
+		r.nextPts = pts + int64(dst.NbSamples)
+		dst.Pts = avutil.RescaleQ(pts, Rat(1, r.dst.SampleRate), r.dst.timeBase())
+	}
+
+	return nil
+}
+
+// ResampleFrame converts the frame to the destination format. If frame is nil,
+// buffered samples are flushed. A nil frame is returned if no output is
+// available yet.
+func (r *Resampler) ResampleFrame(frame *Frame) (*Frame, error) {
+	out := NewFrame()
+	if err := r.ResampleFrameReuse(out, frame); err != nil {
+		return nil, err
+	}
+
+	if out.NbSamples == 0 {
+		return nil, nil
+	}
+
+	return out, nil
+}
+
+// Flush returns all samples that are still buffered in the resampler.
+func (r *Resampler) Flush() ([]*Frame, error) {
+	var frames []*Frame
+	for {
+		frame, err := r.ResampleFrame(nil)
+		if err != nil {
+			return nil, err
+		} else if frame == nil {
+			break
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames, nil
+}
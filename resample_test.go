@@ -0,0 +1,64 @@
+package av_test
+
+import (
+	"testing"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// stereo is the channel layout with the front left and right channels.
+const stereo = 0x3
+
+func TestResampler(t *testing.T) {
+	r, err := av.NewResampler(av.AudioFormat{
+		SampleFormat:  avutil.SampleFormatFLTP,
+		SampleRate:    48000,
+		ChannelLayout: stereo,
+	}, av.AudioFormat{
+		SampleFormat:  avutil.SampleFormatS16,
+		SampleRate:    44100,
+		ChannelLayout: stereo,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in, err := av.NewAudioFrame(4800, avutil.SampleFormatFLTP, stereo, 48000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer in.Free()
+
+	in.Pts = 0
+
+	// frames without a layout are resampled with the default layout of their
+	// channel count
+	in.ChannelLayout = 0
+
+	out := av.NewFrame()
+	defer out.Free()
+
+	var samples int32
+	for _, frame := range []*av.Frame{in, nil} {
+		if err := r.ResampleFrameReuse(out, frame); err != nil {
+			t.Fatal(err)
+		}
+
+		samples += out.NbSamples
+	}
+
+	if in.ChannelLayout != 0 {
+		t.Errorf("the channel layout of the input frame was changed to 0x%x", in.ChannelLayout)
+	}
+
+	if avutil.SampleFormat(out.Format) != avutil.SampleFormatS16 || out.SampleRate != 44100 {
+		t.Errorf("got %s at %d Hz, want s16 at 44100 Hz", avutil.SampleFormat(out.Format), out.SampleRate)
+	}
+
+	// 100ms at 44.1kHz, give or take the rounding of the filter delay
+	if samples < 4408 || samples > 4412 {
+		t.Errorf("got %d samples, want 4410", samples)
+	}
+}
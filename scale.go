@@ -0,0 +1,222 @@
+package av
+
+import (
+	"runtime"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-av/swscale"
+)
+
+type colorDetails struct {
+	srcSpace avutil.ColorSpace
+	srcRange avutil.ColorRange
+	dstSpace avutil.ColorSpace
+	dstRange avutil.ColorRange
+}
+
+// Scaler converts video frames between sizes and pixel formats.
+type Scaler struct {
+	ctx *swscale.Context
+
+	src   VideoFormat
+	dst   VideoFormat
+	flags swscale.Flags
+
+	details colorDetails
+	applied *colorDetails
+}
+
+func newScalerContext(ctx *swscale.Context, src, dst VideoFormat, flags swscale.Flags) (*swscale.Context, error) {
+	if swscale.IsSupportedInput(src.PixelFormat) == 0 {
+		return nil, errors.Errorf("unsupported scaler input pixel format: %s", src.PixelFormat)
+	}
+
+	if swscale.IsSupportedOutput(dst.PixelFormat) == 0 {
+		return nil, errors.Errorf("unsupported scaler output pixel format: %s", dst.PixelFormat)
+	}
+
+	ctx = swscale.GetCachedContext(ctx, src.Width, src.Height, src.PixelFormat, dst.Width, dst.Height, dst.PixelFormat, int32(flags), nil, nil, nil)
+	if ctx == nil {
+		return nil, errors.WithStack(avutil.ErrInval)
+	}
+
+	return ctx, nil
+}
+
+// NewScaler creates a scaler that converts frames of the src format to the
+// dst format using the interpolation algorithm selected by flags.
+func NewScaler(src, dst VideoFormat, flags swscale.Flags) (*Scaler, error) {
+	ctx, err := newScalerContext(nil, src, dst, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Scaler{
+		ctx:   ctx,
+		src:   src,
+		dst:   dst,
+		flags: flags,
+	}
+
+	runtime.SetFinalizer(ret, func(s *Scaler) {
+		swscale.FreeContext(s.ctx)
+	})
+
+	return ret, nil
+}
+
+// NewScalerForEncoder creates a scaler that converts frames produced by the
+// decoder into a format that is accepted by the encoder.
+func NewScalerForEncoder(decoder *DecoderContext, encoder *EncoderContext, flags swscale.Flags) (*Scaler, error) {
+	return NewScaler(decoder.VideoFormat(), encoder.VideoFormat(), flags)
+}
+
+func (s *Scaler) SourceFormat() VideoFormat {
+	return s.src
+}
+
+func (s *Scaler) DestinationFormat() VideoFormat {
+	return s.dst
+}
+
+// SetColorspaceDetails overrides the colorspace and color range used for the
+// conversion. Unspecified source values are taken from each source frame and
+// unspecified destination values default to the source values, except for the
+// range of rgb destinations which is always full.
+func (s *Scaler) SetColorspaceDetails(srcSpace avutil.ColorSpace, srcRange avutil.ColorRange, dstSpace avutil.ColorSpace, dstRange avutil.ColorRange) {
+	s.details = colorDetails{
+		srcSpace: srcSpace,
+		srcRange: srcRange,
+		dstSpace: dstSpace,
+		dstRange: dstRange,
+	}
+}
+
+func (s *Scaler) resolveColorDetails(frame *avutil.Frame) colorDetails {
+	details := s.details
+	if details.srcSpace == avutil.ColorSpaceUnspecified {
+		details.srcSpace = avutil.ColorSpace(frame.Colorspace)
+	}
+
+	if details.srcRange == avutil.ColorRangeUnspecified {
+		details.srcRange = avutil.ColorRange(frame.ColorRange)
+	}
+
+	if details.dstSpace == avutil.ColorSpaceUnspecified {
+		details.dstSpace = details.srcSpace
+	}
+
+	if details.dstRange == avutil.ColorRangeUnspecified {
+		details.dstRange = details.srcRange
+
+		// swscale always outputs full range rgb
+		if s.dst.PixelFormat.IsRGB() {
+			details.dstRange = avutil.ColorRangeJPEG
+		}
+	}
+
+	return details
+}
+
+func swsColorRange(r avutil.ColorRange) int32 {
+	if r == avutil.ColorRangeJPEG {
+		return 1
+	}
+
+	return 0
+}
+
+func (s *Scaler) applyColorDetails(details colorDetails) {
+	if s.applied != nil && *s.applied == details {
+		return
+	}
+
+	// not all conversions take colorspace details into account, in which case
+	// swscale reports an error that is safe to ignore
+	_ = swscale.SetColorspaceDetails(
+		s.ctx,
+		swscale.GetCoefficients(int32(details.srcSpace)),
+		swsColorRange(details.srcRange),
+		swscale.GetCoefficients(int32(details.dstSpace)),
+		swsColorRange(details.dstRange),
+		0,
+		1<<16,
+		1<<16,
+	)
+
+	s.applied = &details
+}
+
+func (s *Scaler) prepareDestination(frame *Frame) error {
+	dst := frame._frame
+	if dst.Data[0] != nil && frameVideoFormat(dst) == s.dst {
+		return averror(avutil.MakeFrameWritable(dst))
+	}
+
+	dst = frame.prepare()
+	dst.Width = s.dst.Width
+	dst.Height = s.dst.Height
+	dst.Format = int32(s.dst.PixelFormat)
+
+	return averror(avutil.GetFrameBuffer(dst, 0))
+}
+
+// ScaleFrameReuse converts the src frame and writes the result to dst. The
+// buffers of dst are reused if they already match the destination format.
+func (s *Scaler) ScaleFrameReuse(dst, src *Frame) error {
+	if src._frame.HwFramesCtx != nil {
+		return errors.New("hardware frames must be transferred to system memory before scaling")
+	}
+
+	if format := frameVideoFormat(src._frame); format != s.src {
+		ctx, err := newScalerContext(s.ctx, format, s.dst, s.flags)
+		if err != nil {
+			return err
+		}
+
+		s.ctx = ctx
+		s.src = format
+		s.applied = nil
+	}
+
+	if err := s.prepareDestination(dst); err != nil {
+		return err
+	}
+
+	details := s.resolveColorDetails(src._frame)
+	s.applyColorDetails(details)
+
+	defer runtime.KeepAlive(src)
+	defer runtime.KeepAlive(dst)
+
+	if err := averror(avutil.CopyFrameProps(dst._frame, src._frame)); err != nil {
+		return err
+	}
+
+	dst._frame.Colorspace = uint32(details.dstSpace)
+	dst._frame.ColorRange = uint32(details.dstRange)
+
+	// the yuv colorspace only applies to the conversion, rgb frames have none
+	// and are always written in full range
+	if s.dst.PixelFormat.IsRGB() {
+		dst._frame.Colorspace = uint32(avutil.ColorSpaceRGB)
+		dst._frame.ColorRange = uint32(avutil.ColorRangeJPEG)
+	}
+
+	if _, err := avreturn(swscale.Scale(s.ctx, &src._frame.Data[0], &src._frame.Linesize[0], 0, src._frame.Height, &dst._frame.Data[0], &dst._frame.Linesize[0])); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ScaleFrame converts the frame to the destination format.
+func (s *Scaler) ScaleFrame(frame *Frame) (*Frame, error) {
+	out := NewFrame()
+	if err := s.ScaleFrameReuse(out, frame); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
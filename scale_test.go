@@ -0,0 +1,133 @@
+package av_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-av/swscale"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+func TestScalerRGBRange(t *testing.T) {
+	src, err := av.NewVideoFrame(4, 4, avutil.PixelFormatYUV420P)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer src.Free()
+
+	src.ColorRange = uint32(avutil.ColorRangeMPEG)
+	src.Colorspace = uint32(avutil.ColorSpaceBT709)
+
+	// limited range white
+	for i := range src.Plane(0) {
+		src.Plane(0)[i] = 235
+	}
+
+	for _, plane := range []int{1, 2} {
+		for i := range src.Plane(plane) {
+			src.Plane(plane)[i] = 128
+		}
+	}
+
+	s, err := av.NewScaler(
+		av.VideoFormat{Width: 4, Height: 4, PixelFormat: avutil.PixelFormatYUV420P},
+		av.VideoFormat{Width: 8, Height: 8, PixelFormat: avutil.PixelFormatRGBA},
+		swscale.Bilinear,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := s.ScaleFrame(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer dst.Free()
+
+	if dst.Width != 8 || dst.Height != 8 || avutil.PixelFormat(dst.Format) != avutil.PixelFormatRGBA {
+		t.Fatalf("got %dx%d %s, want 8x8 rgba", dst.Width, dst.Height, avutil.PixelFormat(dst.Format))
+	}
+
+	if r := avutil.ColorRange(dst.ColorRange); r != avutil.ColorRangeJPEG {
+		t.Errorf("got color range %s, want jpeg", r)
+	}
+
+	if cs := avutil.ColorSpace(dst.Colorspace); cs != avutil.ColorSpaceRGB {
+		t.Errorf("got colorspace %s, want rgb", cs)
+	}
+
+	if p := dst.Plane(0); p[0] < 250 || p[1] < 250 || p[2] < 250 {
+		t.Errorf("got %v, want white", p[:4])
+	}
+}
+
+func TestScalerDecodedFrame(t *testing.T) {
+	input := openClip(t, testClip(t, time.Second))
+
+	var index int32 = -1
+	for i, stream := range input.Streams() {
+		if stream.Codecpar().CodecType == avutil.Video {
+			index = int32(i)
+			break
+		}
+	}
+
+	it, err := av.NewFrameIterator(input, index)
+	if err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	defer it.Close()
+
+	frame := av.NewFrame()
+	defer frame.Free()
+
+	if err := it.Next(frame); err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	s, err := av.NewScaler(
+		av.VideoFormat{Width: frame.Width, Height: frame.Height, PixelFormat: avutil.PixelFormat(frame.Format)},
+		av.VideoFormat{Width: frame.Width / 4, Height: frame.Height / 4, PixelFormat: avutil.PixelFormatRGBA},
+		swscale.Bilinear,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := av.NewFrame()
+	defer out.Free()
+
+	// the second frame reuses the buffers of the first
+	for i := 0; i < 2; i++ {
+		if err := s.ScaleFrameReuse(out, frame); err != nil {
+			t.Fatal(fmterrors.FormatString(err))
+		}
+
+		if out.Pts != frame.Pts {
+			t.Errorf("got pts %d, want %d", out.Pts, frame.Pts)
+		}
+
+		if err := it.Next(frame); err != nil {
+			t.Fatal(fmterrors.FormatString(err))
+		}
+	}
+
+	img, err := out.Image()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := img.(*image.NRGBA); !ok {
+		t.Errorf("got %T, want *image.NRGBA", img)
+	}
+
+	if img.Bounds() != image.Rect(0, 0, 320, 180) {
+		t.Errorf("got bounds %s, want 320x180", img.Bounds())
+	}
+}
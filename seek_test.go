@@ -0,0 +1,69 @@
+package av_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+// streamPosition returns the position of ts in the timeline of the stream.
+func streamPosition(stream *av.Stream, ts int64) time.Duration {
+	if stream.StartTime != avutil.NoPtsValue {
+		ts -= stream.StartTime
+	}
+
+	return time.Duration(avutil.RescaleQ(ts, stream.TimeBase, avutil.Rat(1, 1000000))) * time.Microsecond
+}
+
+func TestInputSeekTo(t *testing.T) {
+	input := openTestMedia(t)
+
+	if err := input.SeekTo(30 * time.Second); err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	packet, err := input.ReadPacket()
+	if err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	defer packet.Free()
+
+	// the seek lands on the closest keyframe before the position
+	pos := streamPosition(input.Stream(int(packet.StreamIndex)), packet.Dts)
+	if pos > 30*time.Second || pos < 20*time.Second {
+		t.Errorf("got packet at %s, want shortly before 30s", pos)
+	}
+}
+
+func TestFrameIteratorSeekTo(t *testing.T) {
+	input := openTestMedia(t)
+	stream := input.Stream(0)
+
+	it, err := av.NewFrameIterator(input, 0)
+	if err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	defer it.Close()
+
+	frame := av.NewFrame()
+	defer frame.Free()
+
+	// seeking backwards must not return frames that were buffered before
+	for _, target := range []time.Duration{10 * time.Second, 2500 * time.Millisecond, 45 * time.Second} {
+		if err := it.SeekTo(target, frame); err != nil {
+			t.Fatal(fmterrors.FormatString(err))
+		}
+
+		// the first frame at or after the target, which is within a frame
+		// duration at 24 fps, give or take the rounding of the timestamps
+		pos := streamPosition(stream, frame.BestEffortTimestamp)
+		if pos < target-time.Millisecond || pos > target+50*time.Millisecond {
+			t.Errorf("seeking to %s returned a frame at %s", target, pos)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package av
+
+import (
+	"math"
+	"reflect"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avcodec"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+func sideDataBytes(data *uint8, size int32) []byte {
+	if data == nil {
+		return nil
+	}
+
+	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
+		Data: uintptr(unsafe.Pointer(data)),
+		Len:  int(size),
+		Cap:  int(size),
+	}))
+}
+
+func checkSideDataSize(b []byte) error {
+	if len(b) > math.MaxInt32-avcodec.InputBufferPaddingSize {
+		return errors.Errorf("side data size too large: %d", len(b))
+	}
+
+	return nil
+}
+
+type _packetSideData = avcodec.PacketSideData
+
+type PacketSideData struct {
+	*_packetSideData
+}
+
+// Bytes returns the payload of the side data. The slice refers to memory owned
+// by the packet, so it is only valid until the side data is removed or the
+// packet is unreferenced or freed.
+func (sd *PacketSideData) Bytes() []byte {
+	return sideDataBytes(sd.Data, sd.Size)
+}
+
+func (sd *PacketSideData) DisplayMatrix() (DisplayMatrix, error) {
+	if sd.Type != avcodec.PacketSideDataDisplayMatrix {
+		return DisplayMatrix{}, errors.Errorf("expected display matrix side data, but got %s", sd.Type)
+	}
+
+	return parseDisplayMatrix(sd.Bytes())
+}
+
+func (sd *PacketSideData) MasteringDisplayMetadata() (*MasteringDisplayMetadata, error) {
+	if sd.Type != avcodec.PacketSideDataMasteringDisplayMetadata {
+		return nil, errors.Errorf("expected mastering display metadata side data, but got %s", sd.Type)
+	}
+
+	return parseMasteringDisplayMetadata(sd.Bytes())
+}
+
+func (sd *PacketSideData) ContentLightLevel() (*ContentLightLevel, error) {
+	if sd.Type != avcodec.PacketSideDataContentLightLevel {
+		return nil, errors.Errorf("expected content light level side data, but got %s", sd.Type)
+	}
+
+	return parseContentLightLevel(sd.Bytes())
+}
+
+func (p *Packet) sideData() []avcodec.PacketSideData {
+	if p.freed() {
+		return nil
+	}
+
+	return *(*[]avcodec.PacketSideData)(unsafe.Pointer(&reflect.SliceHeader{
+		Data: uintptr(unsafe.Pointer(p._packet.SideData)),
+		Len:  int(p.SideDataElems),
+		Cap:  int(p.SideDataElems),
+	}))
+}
+
+// SideData returns the side data attached to the packet.
+func (p *Packet) SideData() []*PacketSideData {
+	sideData := p.sideData()
+	ret := make([]*PacketSideData, len(sideData))
+	for i := range sideData {
+		ret[i] = &PacketSideData{_packetSideData: &sideData[i]}
+	}
+
+	return ret
+}
+
+// SideDataOfType returns the side data of the given type or nil if the packet
+// has none.
+func (p *Packet) SideDataOfType(t avcodec.PacketSideDataType) *PacketSideData {
+	sideData := p.sideData()
+	for i := range sideData {
+		if sideData[i].Type == t {
+			return &PacketSideData{_packetSideData: &sideData[i]}
+		}
+	}
+
+	return nil
+}
+
+// AddSideData attaches a copy of b to the packet as side data of the given
+// type, replacing any existing side data of the same type.
+func (p *Packet) AddSideData(t avcodec.PacketSideDataType, b []byte) (*PacketSideData, error) {
+	if p.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
+	if err := checkSideDataSize(b); err != nil {
+		return nil, err
+	}
+
+	p.RemoveSideData(t)
+
+	data := avcodec.NewPacketSideData(p._packet, t, int32(len(b)))
+	if data == nil {
+		panic(avutil.ErrNoMem)
+	}
+
+	copy(sideDataBytes(data, int32(len(b))), b)
+
+	return p.SideDataOfType(t), nil
+}
+
+// RemoveSideData removes all side data of the given type from the packet.
+func (p *Packet) RemoveSideData(t avcodec.PacketSideDataType) {
+	if p.freed() {
+		return
+	}
+
+	sideData := p.sideData()
+	for i := 0; i < len(sideData); {
+		if sideData[i].Type != t {
+			i++
+			continue
+		}
+
+		avutil.Free(unsafe.Pointer(sideData[i].Data))
+
+		// the order of side data is insignificant, so just fill the gap with
+		// the last element
+		sideData[i] = sideData[len(sideData)-1]
+		sideData = sideData[:len(sideData)-1]
+	}
+
+	p.SideDataElems = int32(len(sideData))
+}
+
+type _frameSideData = avutil.FrameSideData
+
+type FrameSideData struct {
+	*_frameSideData
+}
+
+// Bytes returns the payload of the side data. The slice refers to memory owned
+// by the frame, so it is only valid until the side data is removed or the
+// frame is unreferenced or freed.
+func (sd *FrameSideData) Bytes() []byte {
+	return sideDataBytes(sd.Data, sd.Size)
+}
+
+func (sd *FrameSideData) Metadata() map[string]string {
+	return dictToMap(sd._frameSideData.Metadata)
+}
+
+func (sd *FrameSideData) DisplayMatrix() (DisplayMatrix, error) {
+	if sd.Type != avutil.FrameSideDataDisplayMatrix {
+		return DisplayMatrix{}, errors.Errorf("expected display matrix side data, but got %s", sd.Type)
+	}
+
+	return parseDisplayMatrix(sd.Bytes())
+}
+
+func (sd *FrameSideData) MasteringDisplayMetadata() (*MasteringDisplayMetadata, error) {
+	if sd.Type != avutil.FrameSideDataMasteringDisplayMetadata {
+		return nil, errors.Errorf("expected mastering display metadata side data, but got %s", sd.Type)
+	}
+
+	return parseMasteringDisplayMetadata(sd.Bytes())
+}
+
+func (sd *FrameSideData) ContentLightLevel() (*ContentLightLevel, error) {
+	if sd.Type != avutil.FrameSideDataContentLightLevel {
+		return nil, errors.Errorf("expected content light level side data, but got %s", sd.Type)
+	}
+
+	return parseContentLightLevel(sd.Bytes())
+}
+
+func (f *Frame) sideData() []*avutil.FrameSideData {
+	if f.freed() {
+		return nil
+	}
+
+	return *(*[]*avutil.FrameSideData)(unsafe.Pointer(&reflect.SliceHeader{
+		Data: uintptr(unsafe.Pointer(f._frame.SideData)),
+		Len:  int(f.NbSideData),
+		Cap:  int(f.NbSideData),
+	}))
+}
+
+// SideData returns the side data attached to the frame.
+func (f *Frame) SideData() []*FrameSideData {
+	sideData := f.sideData()
+	ret := make([]*FrameSideData, len(sideData))
+	for i, sd := range sideData {
+		ret[i] = &FrameSideData{_frameSideData: sd}
+	}
+
+	return ret
+}
+
+// SideDataOfType returns the side data of the given type or nil if the frame
+// has none.
+func (f *Frame) SideDataOfType(t avutil.FrameSideDataType) *FrameSideData {
+	for _, sd := range f.sideData() {
+		if sd.Type == t {
+			return &FrameSideData{_frameSideData: sd}
+		}
+	}
+
+	return nil
+}
+
+// AddSideData attaches a copy of b to the frame as side data of the given
+// type, replacing any existing side data of the same type.
+func (f *Frame) AddSideData(t avutil.FrameSideDataType, b []byte) (*FrameSideData, error) {
+	if f.freed() {
+		return nil, errors.WithStack(ErrClosed)
+	}
+
+	if err := checkSideDataSize(b); err != nil {
+		return nil, err
+	}
+
+	f.RemoveSideData(t)
+
+	sd := avutil.NewFrameSideData(f._frame, t, int32(len(b)))
+	if sd == nil {
+		panic(avutil.ErrNoMem)
+	}
+
+	copy(sideDataBytes(sd.Data, sd.Size), b)
+
+	return &FrameSideData{_frameSideData: sd}, nil
+}
+
+// RemoveSideData removes all side data of the given type from the frame.
+func (f *Frame) RemoveSideData(t avutil.FrameSideDataType) {
+	if f.freed() {
+		return
+	}
+
+	avutil.RemoveFrameSideData(f._frame, t)
+}
+
+// DisplayMatrix is a 3x3 transformation matrix, in row-major order, that
+// describes how a picture should be transformed for display.
+type DisplayMatrix [9]int32
+
+// NewDisplayMatrix returns a display matrix that rotates the picture
+// counterclockwise by the given angle in degrees.
+func NewDisplayMatrix(rotation float64) DisplayMatrix {
+	var m DisplayMatrix
+	avutil.SetDisplayRotation(&m[0], rotation)
+	return m
+}
+
+func parseDisplayMatrix(b []byte) (DisplayMatrix, error) {
+	var m DisplayMatrix
+	if len(b) < int(unsafe.Sizeof(m)) {
+		return m, errors.Errorf("display matrix too short: %d bytes", len(b))
+	}
+
+	return *(*DisplayMatrix)(unsafe.Pointer(&b[0])), nil
+}
+
+// Rotation returns the counterclockwise rotation of the matrix in degrees,
+// within the range [-180, 180]. NaN is returned if the matrix is singular.
+func (m DisplayMatrix) Rotation() float64 {
+	return avutil.GetDisplayRotation(&m[0])
+}
+
+// Bytes returns the matrix in the format that is used for side data.
+func (m DisplayMatrix) Bytes() []byte {
+	b := make([]byte, unsafe.Sizeof(m))
+	*(*DisplayMatrix)(unsafe.Pointer(&b[0])) = m
+	return b
+}
+
+// MasteringDisplayMetadata describes the color volume of the display that was
+// used to master the content, as defined by SMPTE ST 2086.
+type MasteringDisplayMetadata struct {
+	// DisplayPrimaries are the CIE 1931 xy chromaticity coordinates of the
+	// red, green and blue primaries of the display.
+	DisplayPrimaries [3][2]avutil.Rational
+
+	// WhitePoint is the CIE 1931 xy chromaticity coordinate of the white
+	// point of the display.
+	WhitePoint [2]avutil.Rational
+
+	// MinLuminance and MaxLuminance are the luminance range of the display in
+	// cd/m².
+	MinLuminance avutil.Rational
+	MaxLuminance avutil.Rational
+
+	HasPrimaries bool
+	HasLuminance bool
+}
+
+func parseMasteringDisplayMetadata(b []byte) (*MasteringDisplayMetadata, error) {
+	if len(b) < int(unsafe.Sizeof(avutil.MasteringDisplayMetadata{})) {
+		return nil, errors.Errorf("mastering display metadata too short: %d bytes", len(b))
+	}
+
+	m := (*avutil.MasteringDisplayMetadata)(unsafe.Pointer(&b[0]))
+
+	return &MasteringDisplayMetadata{
+		DisplayPrimaries: m.DisplayPrimaries,
+		WhitePoint:       m.WhitePoint,
+		MinLuminance:     m.MinLuminance,
+		MaxLuminance:     m.MaxLuminance,
+		HasPrimaries:     m.HasPrimaries != 0,
+		HasLuminance:     m.HasLuminance != 0,
+	}, nil
+}
+
+// Bytes returns the metadata in the format that is used for side data.
+func (m *MasteringDisplayMetadata) Bytes() []byte {
+	b := make([]byte, unsafe.Sizeof(avutil.MasteringDisplayMetadata{}))
+	*(*avutil.MasteringDisplayMetadata)(unsafe.Pointer(&b[0])) = avutil.MasteringDisplayMetadata{
+		DisplayPrimaries: m.DisplayPrimaries,
+		WhitePoint:       m.WhitePoint,
+		MinLuminance:     m.MinLuminance,
+		MaxLuminance:     m.MaxLuminance,
+		HasPrimaries:     boolToInt32(m.HasPrimaries),
+		HasLuminance:     boolToInt32(m.HasLuminance),
+	}
+
+	return b
+}
+
+// ContentLightLevel describes the light level of the content, as defined by
+// CEA-861.3.
+type ContentLightLevel struct {
+	// MaxCLL is the maximum content light level in cd/m².
+	MaxCLL uint32
+
+	// MaxFALL is the maximum frame-average light level in cd/m².
+	MaxFALL uint32
+}
+
+func parseContentLightLevel(b []byte) (*ContentLightLevel, error) {
+	if len(b) < int(unsafe.Sizeof(avutil.ContentLightMetadata{})) {
+		return nil, errors.Errorf("content light level too short: %d bytes", len(b))
+	}
+
+	m := (*avutil.ContentLightMetadata)(unsafe.Pointer(&b[0]))
+
+	return &ContentLightLevel{
+		MaxCLL:  m.MaxCLL,
+		MaxFALL: m.MaxFALL,
+	}, nil
+}
+
+// Bytes returns the light level in the format that is used for side data.
+func (l *ContentLightLevel) Bytes() []byte {
+	b := make([]byte, unsafe.Sizeof(avutil.ContentLightMetadata{}))
+	*(*avutil.ContentLightMetadata)(unsafe.Pointer(&b[0])) = avutil.ContentLightMetadata{
+		MaxCLL:  l.MaxCLL,
+		MaxFALL: l.MaxFALL,
+	}
+
+	return b
+}
+
+func boolToInt32(v bool) int32 {
+	if v {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,50 @@
+package av_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ssttevee/go-av"
+)
+
+func TestDisplayMatrixRotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		rotation float64
+	}{
+		{"identity", 0},
+		{"counterclockwise", 90},
+		{"clockwise", -90},
+		{"diagonal", 45},
+		{"obtuse", -135},
+		{"upside down", 180},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := av.NewDisplayMatrix(tt.rotation)
+
+			got := m.Rotation()
+
+			// 180 and -180 degrees are the same rotation
+			if math.Abs(tt.rotation) == 180 {
+				got = math.Abs(got)
+			}
+
+			if math.Abs(got-tt.rotation) > 1e-3 {
+				t.Errorf("NewDisplayMatrix(%g).Rotation() = %g", tt.rotation, got)
+			}
+
+			if n := len(m.Bytes()); n != 36 {
+				t.Errorf("len(Bytes()) = %d, want 36", n)
+			}
+		})
+	}
+}
+
+func TestDisplayMatrixSingular(t *testing.T) {
+	var m av.DisplayMatrix
+	if got := m.Rotation(); !math.IsNaN(got) {
+		t.Errorf("Rotation() = %g, want NaN", got)
+	}
+}
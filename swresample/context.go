@@ -0,0 +1,3 @@
+package swresample
+
+type Context struct{}
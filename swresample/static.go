@@ -0,0 +1,6 @@
+// +build !av_dynamic
+
+package swresample
+
+// #cgo pkg-config: libswresample
+import "C"
@@ -0,0 +1,26 @@
+//go:generate go run github.com/ssttevee/go-av/generate
+
+package swresample
+
+// #include <libswresample/swresample.h>
+import "C"
+
+// +gen convtype struct_AVFrame github.com/ssttevee/go-av/avutil.Frame
+
+// +gen convtype struct_SwrContext Context
+
+// +gen wrapfunc swr_alloc NewContext
+// +gen wrapfunc swr_alloc_set_opts AllocSetOpts
+// +gen wrapfunc swr_init Init
+// +gen wrapfunc swr_is_initialized IsInitialized
+// +gen wrapfunc swr_close Close
+// +gen wrapfunc swr_free FreeContext
+// +gen wrapfunc swr_convert Convert
+// +gen wrapfunc swr_convert_frame ConvertFrame
+// +gen wrapfunc swr_config_frame ConfigFrame
+// +gen wrapfunc swr_next_pts NextPts
+// +gen wrapfunc swr_get_delay GetDelay
+// +gen wrapfunc swr_get_out_samples GetOutSamples
+
+// +gen paramtype swr_alloc_set_opts 2 github.com/ssttevee/go-av/avutil.SampleFormat
+// +gen paramtype swr_alloc_set_opts 5 github.com/ssttevee/go-av/avutil.SampleFormat
@@ -0,0 +1,3 @@
+package swscale
+
+type Context struct{}
@@ -0,0 +1,9 @@
+// +build av_dynamic
+
+package swscale
+
+import "github.com/ssttevee/go-av/avutil"
+
+func init() {
+	initFuncs = append(initFuncs, avutil.InitLogging)
+}
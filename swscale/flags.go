@@ -0,0 +1,25 @@
+package swscale
+
+// #include <libswscale/swscale.h>
+import "C"
+
+type Flags int32
+
+const (
+	FastBilinear = Flags(C.SWS_FAST_BILINEAR)
+	Bilinear     = Flags(C.SWS_BILINEAR)
+	Bicubic      = Flags(C.SWS_BICUBIC)
+	Experimental = Flags(C.SWS_X)
+	Point        = Flags(C.SWS_POINT)
+	Area         = Flags(C.SWS_AREA)
+	Bicublin     = Flags(C.SWS_BICUBLIN)
+	Gauss        = Flags(C.SWS_GAUSS)
+	Sinc         = Flags(C.SWS_SINC)
+	Lanczos      = Flags(C.SWS_LANCZOS)
+	Spline       = Flags(C.SWS_SPLINE)
+
+	AccurateRound = Flags(C.SWS_ACCURATE_RND)
+	FullChromaInt = Flags(C.SWS_FULL_CHR_H_INT)
+	FullChromaInp = Flags(C.SWS_FULL_CHR_H_INP)
+	BitExact      = Flags(C.SWS_BITEXACT)
+)
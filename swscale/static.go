@@ -0,0 +1,6 @@
+// +build !av_dynamic
+
+package swscale
+
+// #cgo pkg-config: libswscale
+import "C"
@@ -0,0 +1,24 @@
+//go:generate go run github.com/ssttevee/go-av/generate
+
+package swscale
+
+// #include <libswscale/swscale.h>
+import "C"
+
+// +gen convtype struct_SwsContext Context
+
+// +gen wrapfunc sws_getContext NewContext
+// +gen wrapfunc sws_getCachedContext GetCachedContext
+// +gen wrapfunc sws_freeContext FreeContext
+// +gen wrapfunc sws_scale Scale
+// +gen wrapfunc sws_setColorspaceDetails SetColorspaceDetails
+// +gen wrapfunc sws_getCoefficients GetCoefficients
+// +gen wrapfunc sws_isSupportedInput IsSupportedInput
+// +gen wrapfunc sws_isSupportedOutput IsSupportedOutput
+
+// +gen paramtype sws_getContext 2 github.com/ssttevee/go-av/avutil.PixelFormat
+// +gen paramtype sws_getContext 5 github.com/ssttevee/go-av/avutil.PixelFormat
+// +gen paramtype sws_getCachedContext 3 github.com/ssttevee/go-av/avutil.PixelFormat
+// +gen paramtype sws_getCachedContext 6 github.com/ssttevee/go-av/avutil.PixelFormat
+// +gen paramtype sws_isSupportedInput 0 github.com/ssttevee/go-av/avutil.PixelFormat
+// +gen paramtype sws_isSupportedOutput 0 github.com/ssttevee/go-av/avutil.PixelFormat
@@ -0,0 +1,606 @@
+package av
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av/avcodec"
+	"github.com/ssttevee/go-av/avformat"
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// StreamMode describes what a Pipeline does with an input stream.
+type StreamMode int
+
+const (
+	// StreamCopy writes the packets of the stream to the output without
+	// decoding them.
+	StreamCopy StreamMode = iota
+
+	// StreamTranscode decodes, filters and re-encodes the stream.
+	StreamTranscode
+
+	// StreamDrop leaves the stream out of the output.
+	StreamDrop
+)
+
+// StreamOptions configures how a Pipeline handles a single input stream.
+type StreamOptions struct {
+	Mode StreamMode
+
+	// Filter is a filter graph description with exactly one input and one
+	// output, like "scale=1280:-2" or "aresample=48000". The frames are passed
+	// through unchanged if it is empty. Only used with StreamTranscode.
+	Filter string
+
+	// Encoder is the name of the encoder to use. The encoder for the input
+	// codec is used if it is empty. Only used with StreamTranscode.
+	Encoder string
+
+	// ConfigureEncoder is called before the encoder is opened, after its
+	// format has been set from the output of the filter graph.
+	ConfigureEncoder func(encoder *EncoderContext, decoder *DecoderContext) error
+}
+
+// Pipeline reads packets from an input, copies, transcodes or drops them
+// stream by stream and writes the result to an output.
+type Pipeline struct {
+	Input  *InputFormatContext
+	Output *OutputFormatContext
+
+	// Streams returns the options for the input stream at the given index.
+	// All streams are copied if it is nil.
+	Streams func(index int, stream *Stream) StreamOptions
+
+	// CopyChapters copies the chapters of the input to the output.
+	CopyChapters bool
+}
+
+// Transcode runs a Pipeline from input to output.
+func Transcode(ctx context.Context, input *InputFormatContext, output *OutputFormatContext, streams func(index int, stream *Stream) StreamOptions) error {
+	return (&Pipeline{
+		Input:   input,
+		Output:  output,
+		Streams: streams,
+	}).Run(ctx)
+}
+
+type pipelineStream interface {
+	writePacket(packet *Packet) error
+	flush() error
+	free()
+}
+
+// Run processes the whole input. All decoders, filters and encoders are
+// drained once the end of the input is reached and the output is closed. They
+// are freed when Run returns.
+func (p *Pipeline) Run(ctx context.Context) error {
+	inputStreams := p.Input.Streams()
+	streams := make([]pipelineStream, len(inputStreams))
+	defer func() {
+		for _, stream := range streams {
+			if stream != nil {
+				stream.free()
+			}
+		}
+	}()
+
+	for i, in := range inputStreams {
+		var opts StreamOptions
+		if p.Streams != nil {
+			opts = p.Streams(i, in)
+		}
+
+		var err error
+		switch opts.Mode {
+		case StreamCopy:
+			streams[i] = p.newCopyStream(in)
+
+		case StreamTranscode:
+			var s *transcodeStream
+			if s, err = p.newTranscodeStream(in, opts); err == nil {
+				streams[i] = s
+			}
+
+		case StreamDrop:
+
+		default:
+			err = errors.Errorf("unknown stream mode: %d", opts.Mode)
+		}
+
+		if err != nil {
+			return errors.WithMessagef(err, "stream %d", i)
+		}
+	}
+
+	if p.CopyChapters {
+		if err := p.Output.CopyChapters(p.Input, 0, 0); err != nil {
+			return err
+		}
+	}
+
+	// writes to the output are aborted when ctx is done as well
+	return p.Output.withContext(ctx, func() error {
+		return p.run(ctx, streams)
+	})
+}
+
+func (p *Pipeline) run(ctx context.Context, streams []pipelineStream) error {
+	// the muxer may change the time base of the output streams while writing
+	// the header, so it must be written before any packet is rescaled
+	if err := p.Output.init(); err != nil {
+		return err
+	}
+
+	packet := packetPool.Get()
+	defer packetPool.Put(packet)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := p.Input.ReadPacketReuseContext(ctx, packet); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if int(packet.StreamIndex) >= len(streams) || streams[packet.StreamIndex] == nil {
+			continue
+		}
+
+		if err := streams[packet.StreamIndex].writePacket(packet); err != nil {
+			return errors.WithMessagef(err, "stream %d", packet.StreamIndex)
+		}
+	}
+
+	for i, stream := range streams {
+		if stream == nil {
+			continue
+		}
+
+		if err := stream.flush(); err != nil {
+			return errors.WithMessagef(err, "stream %d", i)
+		}
+	}
+
+	return p.Output.Close()
+}
+
+func (p *Pipeline) writePacket(packet *Packet, out *Stream, timeBase avutil.Rational) error {
+	packet.StreamIndex = out.Index
+	packet.Pos = -1
+	packet.Rescale(timeBase, out.TimeBase)
+
+	return p.Output.WritePacket(packet)
+}
+
+type copyStream struct {
+	p   *Pipeline
+	in  *Stream
+	out *Stream
+}
+
+func (p *Pipeline) newCopyStream(in *Stream) *copyStream {
+	out := p.Output.NewStream(nil)
+	out.SetCodecpar(in.Codecpar())
+	out.TimeBase = in.TimeBase
+	out._stream.Codecpar.CodecTag = 0
+
+	return &copyStream{
+		p:   p,
+		in:  in,
+		out: out,
+	}
+}
+
+func (s *copyStream) writePacket(packet *Packet) error {
+	return s.p.writePacket(packet, s.out, s.in.TimeBase)
+}
+
+func (s *copyStream) flush() error {
+	return nil
+}
+
+func (s *copyStream) free() {}
+
+type transcodeStream struct {
+	p   *Pipeline
+	in  *Stream
+	out *Stream
+
+	decoder *DecoderContext
+	graph   *FilterGraph
+	src     *BufferSource
+	sink    *BufferSink
+	encoder *EncoderContext
+
+	// sinkTimeBase is the time base of the filtered frames
+	sinkTimeBase avutil.Rational
+
+	frame    *Frame
+	filtered *Frame
+	packet   *Packet
+}
+
+func (p *Pipeline) newTranscodeStream(in *Stream, opts StreamOptions) (*transcodeStream, error) {
+	s := &transcodeStream{
+		p:        p,
+		in:       in,
+		frame:    framePool.Get(),
+		filtered: framePool.Get(),
+		packet:   packetPool.Get(),
+	}
+
+	if err := s.init(opts); err != nil {
+		s.free()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *transcodeStream) init(opts StreamOptions) error {
+	if err := s.initDecoder(); err != nil {
+		return err
+	}
+
+	var encoderCodec *Codec
+	var err error
+	if opts.Encoder != "" {
+		encoderCodec, err = FindEncoderCodecByName(opts.Encoder)
+	} else {
+		encoderCodec, err = FindEncoderCodecByID(s.decoder.CodecID())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.initFilterGraph(opts.Filter, encoderCodec); err != nil {
+		return err
+	}
+
+	if err := s.initEncoder(encoderCodec, opts.ConfigureEncoder); err != nil {
+		return err
+	}
+
+	s.out = s.p.Output.NewStream(nil)
+	s.out.SetCodecpar(s.encoder.CodecParameters())
+	s.out.TimeBase = s.encoder.TimeBase
+	s.out.SampleAspectRatio = s.encoder.SampleAspectRatio
+
+	return nil
+}
+
+// free returns the frames and the packet to the pools and releases the
+// codecs and the filter graph.
+func (s *transcodeStream) free() {
+	framePool.Put(s.frame)
+	framePool.Put(s.filtered)
+	packetPool.Put(s.packet)
+	s.frame, s.filtered, s.packet = nil, nil, nil
+
+	if s.decoder != nil {
+		s.decoder.Free()
+	}
+
+	if s.graph != nil {
+		s.graph.Free()
+	}
+
+	if s.encoder != nil {
+		s.encoder.Free()
+	}
+}
+
+func (s *transcodeStream) initDecoder() error {
+	codec, err := FindDecoderCodecByID(s.in.Codecpar().CodecID)
+	if err != nil {
+		return err
+	}
+
+	s.decoder, err = NewDecoderContext(codec, s.in.Codecpar())
+	if err != nil {
+		return err
+	}
+
+	s.decoder.PktTimebase = s.in.TimeBase
+	if s.decoder.CodecType == avutil.Video {
+		s.decoder.Framerate = s.p.Input.GuessFramerate(s.in)
+	}
+
+	if err := s.decoder.Open(); err != nil {
+		return err
+	}
+
+	// decoded frames are timestamped in the time base of the stream
+	s.decoder.TimeBase = s.in.TimeBase
+
+	return nil
+}
+
+func (s *transcodeStream) initFilterGraph(desc string, encoderCodec *Codec) error {
+	var err error
+	s.graph, err = NewFilterGraph()
+	if err != nil {
+		return err
+	}
+
+	s.src, err = s.graph.NewBufferSource("in", s.decoder)
+	if err != nil {
+		return err
+	}
+
+	nullFilterName := "null"
+	if s.decoder.CodecType == avutil.Audio {
+		nullFilterName = "anull"
+		s.sink, err = s.graph.NewAudioBufferSink("out")
+	} else {
+		s.sink, err = s.graph.NewBufferSink("out")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if desc == "" {
+		desc = nullFilterName
+	}
+
+	inputs, outputs, err := s.graph.Parse(desc)
+	if err != nil {
+		return err
+	}
+
+	if len(inputs) != 1 || len(outputs) != 1 {
+		return errors.Errorf("filter graph must have exactly one input and one output, but got %d inputs and %d outputs", len(inputs), len(outputs))
+	}
+
+	if err := inputs[0].FilterContext.LinkFrom(inputs[0].PadIndex, (*FilterContext)(s.src), 0); err != nil {
+		return err
+	}
+
+	last, lastPadIndex := outputs[0].FilterContext, outputs[0].PadIndex
+
+	// restrict the output of the graph to the formats the encoder supports
+	if filterName, args := encoderFormatFilterArgs(encoderCodec); args != "" {
+		formatFilter, err := s.graph.NewFilterByName(filterName, "encoder_format", args)
+		if err != nil {
+			return err
+		}
+
+		if err := last.LinkTo(lastPadIndex, formatFilter, 0); err != nil {
+			return err
+		}
+
+		last, lastPadIndex = formatFilter, 0
+	}
+
+	return s.sink.LinkFrom(last, lastPadIndex)
+}
+
+func encoderFormatFilterArgs(codec *Codec) (string, string) {
+	var args []string
+	switch codec.Type {
+	case avutil.Video:
+		if fmts := codec.PixFmts(); len(fmts) > 0 {
+			names := make([]string, len(fmts))
+			for i, f := range fmts {
+				names[i] = f.String()
+			}
+
+			args = append(args, "pix_fmts="+strings.Join(names, "|"))
+		}
+
+		return "format", strings.Join(args, ":")
+
+	case avutil.Audio:
+		if fmts := codec.SampleFmts(); len(fmts) > 0 {
+			names := make([]string, len(fmts))
+			for i, f := range fmts {
+				names[i] = f.String()
+			}
+
+			args = append(args, "sample_fmts="+strings.Join(names, "|"))
+		}
+
+		if rates := codec.SupportedSampleRates(); len(rates) > 0 {
+			names := make([]string, len(rates))
+			for i, rate := range rates {
+				names[i] = fmt.Sprint(rate)
+			}
+
+			args = append(args, "sample_rates="+strings.Join(names, "|"))
+		}
+
+		if layouts := codec.ChannelLayouts(); len(layouts) > 0 {
+			names := make([]string, len(layouts))
+			for i, layout := range layouts {
+				names[i] = fmt.Sprintf("0x%x", layout)
+			}
+
+			args = append(args, "channel_layouts="+strings.Join(names, "|"))
+		}
+
+		return "aformat", strings.Join(args, ":")
+	}
+
+	return "", ""
+}
+
+func (s *transcodeStream) initEncoder(codec *Codec, configure func(*EncoderContext, *DecoderContext) error) error {
+	var err error
+	s.encoder, err = NewEncoderContext(codec, nil)
+	if err != nil {
+		return err
+	}
+
+	timeBase, err := s.sink.TimeBase()
+	if err != nil {
+		return err
+	}
+
+	s.sinkTimeBase = timeBase
+
+	switch codec.Type {
+	case avutil.Video:
+		format, err := s.sink.VideoFormat()
+		if err != nil {
+			return err
+		}
+
+		frameRate, err := s.sink.FrameRate()
+		if err != nil {
+			return err
+		}
+
+		if frameRate.Num <= 0 || frameRate.Den <= 0 {
+			frameRate = s.decoder.Framerate
+		}
+
+		// codecs like mpeg4 reject time bases that are much finer than the
+		// frame rate, like the 1/90000 of mpegts streams
+		if frameRate.Num > 0 && frameRate.Den > 0 {
+			s.encoder.TimeBase = frameRate.Inverse()
+		} else {
+			s.encoder.TimeBase = timeBase
+		}
+
+		s.encoder.Width = format.Width
+		s.encoder.Height = format.Height
+		s.encoder.PixFmt = format.PixelFormat
+		s.encoder.SampleAspectRatio = s.decoder.SampleAspectRatio
+		s.encoder.Framerate = s.decoder.Framerate
+
+	case avutil.Audio:
+		format, err := s.sink.AudioFormat()
+		if err != nil {
+			return err
+		}
+
+		s.encoder.TimeBase = avutil.Rat(1, format.SampleRate)
+		s.encoder.SampleFmt = format.SampleFormat
+		s.encoder.SampleRate = format.SampleRate
+		s.encoder.ChannelLayout = format.ChannelLayout
+		s.encoder.Channels = format.Channels()
+	}
+
+	if s.p.Output.formatFlags()&avformat.GlobalHeader != 0 {
+		s.encoder.Flags |= avcodec.FlagGlobalHeader
+	}
+
+	if configure != nil {
+		if err := configure(s.encoder, s.decoder); err != nil {
+			return err
+		}
+	}
+
+	if err := s.encoder.Open(); err != nil {
+		return err
+	}
+
+	if codec.Type == avutil.Audio {
+		if err := s.sink.SetFrameSizeForEncoder(s.encoder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *transcodeStream) writePacket(packet *Packet) error {
+	if err := s.decoder.SendPacket(packet); err != nil {
+		return err
+	}
+
+	return s.receiveFrames()
+}
+
+func (s *transcodeStream) receiveFrames() error {
+	for {
+		if err := s.decoder.ReceiveFrameReuse(s.frame); errors.Is(err, avutil.ErrAgain) || errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		s.frame.Pts = s.frame.BestEffortTimestamp
+
+		if err := s.src.WriteFrame(s.frame); err != nil {
+			return err
+		}
+
+		if err := s.filterFrames(); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *transcodeStream) filterFrames() error {
+	for {
+		if err := s.sink.ReadFrameReuse(s.filtered); errors.Is(err, avutil.ErrAgain) || errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		// let the encoder decide the picture type
+		s.filtered.PictType = 0
+
+		// the encoder time base may have been changed by ConfigureEncoder
+		if s.filtered.Pts != avutil.NoPtsValue {
+			s.filtered.Pts = avutil.RescaleQ(s.filtered.Pts, s.sinkTimeBase, s.encoder.TimeBase)
+		}
+
+		if err := s.encoder.SendFrame(s.filtered); err != nil {
+			return err
+		}
+
+		if err := s.receivePackets(); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *transcodeStream) receivePackets() error {
+	for {
+		if err := s.encoder.ReceivePacketReuse(s.packet); errors.Is(err, avutil.ErrAgain) || errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := s.p.writePacket(s.packet, s.out, s.encoder.TimeBase); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *transcodeStream) flush() error {
+	if err := s.decoder.SendPacket(nil); err != nil {
+		return err
+	}
+
+	if err := s.receiveFrames(); err != nil {
+		return err
+	}
+
+	if err := s.src.WriteFrame(nil); err != nil {
+		return err
+	}
+
+	if err := s.filterFrames(); err != nil {
+		return err
+	}
+
+	if err := s.encoder.SendFrame(nil); err != nil {
+		return err
+	}
+
+	return s.receivePackets()
+}
@@ -0,0 +1,96 @@
+package av_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/ssttevee/go-av"
+	"github.com/ssttevee/go-av/avutil"
+	"github.com/ssttevee/go-fmterrors"
+)
+
+func TestTranscode(t *testing.T) {
+	input := openClip(t, testClip(t, 2*time.Second))
+
+	var buf bytes.Buffer
+	output, err := av.NewWriterOutputContext("matroska", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = av.Transcode(context.Background(), input, output, func(index int, stream *av.Stream) av.StreamOptions {
+		if stream.Codecpar().CodecType != avutil.Video {
+			return av.StreamOptions{Mode: av.StreamDrop}
+		}
+
+		return av.StreamOptions{
+			Mode:    av.StreamTranscode,
+			Filter:  "scale=160:-2",
+			Encoder: "mpeg4",
+			ConfigureEncoder: func(encoder *av.EncoderContext, decoder *av.DecoderContext) error {
+				// a time base other than the one of the filter output makes
+				// sure that the frames are rescaled before they are encoded
+				encoder.TimeBase = avutil.Rat(1, 1000)
+				return nil
+			},
+		}
+	})
+	if err != nil {
+		t.Fatal(fmterrors.FormatString(err))
+	}
+
+	result := openClip(t, buf.Bytes())
+	if n := len(result.Streams()); n != 1 {
+		t.Fatalf("got %d streams, want 1", n)
+	}
+
+	stream := result.Stream(0)
+	codec, err := av.FindDecoderCodecByID(stream.Codecpar().CodecID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if codec.Name() != "mpeg4" {
+		t.Errorf("got codec %s, want mpeg4", codec.Name())
+	}
+
+	if width := stream.Codecpar().Width; width != 160 {
+		t.Errorf("got width %d, want 160", width)
+	}
+
+	var count int
+	var last time.Duration
+	for {
+		packet, err := result.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		pts := time.Duration(avutil.RescaleQ(packet.Pts, stream.TimeBase, avutil.Rat(1, 1000))) * time.Millisecond
+		if pts < 0 || pts > 2500*time.Millisecond {
+			t.Errorf("packet %d has pts %s outside of the clip", count, pts)
+		}
+
+		if pts > last {
+			last = pts
+		}
+
+		count++
+		packet.Free()
+	}
+
+	// the clip is 2 seconds of 24 fps video
+	if count < 40 || count > 56 {
+		t.Errorf("got %d packets, want about 48", count)
+	}
+
+	if last < 1500*time.Millisecond {
+		t.Errorf("got last pts %s, want about 2s", last)
+	}
+}
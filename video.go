@@ -0,0 +1,20 @@
+package av
+
+import (
+	"github.com/ssttevee/go-av/avutil"
+)
+
+// VideoFormat describes the dimensions and pixel layout of a video frame.
+type VideoFormat struct {
+	Width       int32
+	Height      int32
+	PixelFormat avutil.PixelFormat
+}
+
+func frameVideoFormat(frame *avutil.Frame) VideoFormat {
+	return VideoFormat{
+		Width:       frame.Width,
+		Height:      frame.Height,
+		PixelFormat: avutil.PixelFormat(frame.Format),
+	}
+}